@@ -0,0 +1,921 @@
+// Code generated by astgen. DO NOT EDIT.
+
+package astrav
+
+// List returns the child nodes of ast.CommentGroup.List. The nodes have the same
+// indices as in the ast slice.
+func (s *CommentGroup) List() []*Comment {
+	if len(s.CommentGroup.List) == 0 {
+		return nil
+	}
+	nodes := make([]*Comment, 0, len(s.CommentGroup.List))
+	for _, child := range s.CommentGroup.List {
+		n, _ := s.findChildByAstNode(child).(*Comment)
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// Doc returns the child node of ast.Field.Doc
+func (s *Field) Doc() *CommentGroup {
+	if s.Field.Doc == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.Field.Doc).(*CommentGroup)
+	return n
+}
+
+// Names returns the child nodes of ast.Field.Names. The nodes have the same
+// indices as in the ast slice.
+func (s *Field) Names() []*Ident {
+	if len(s.Field.Names) == 0 {
+		return nil
+	}
+	nodes := make([]*Ident, 0, len(s.Field.Names))
+	for _, child := range s.Field.Names {
+		n, _ := s.findChildByAstNode(child).(*Ident)
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// Type returns the child node of ast.Field.Type
+func (s *Field) Type() Node {
+	if s.Field.Type == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.Field.Type)
+}
+
+// Tag returns the child node of ast.Field.Tag
+func (s *Field) Tag() *BasicLit {
+	if s.Field.Tag == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.Field.Tag).(*BasicLit)
+	return n
+}
+
+// Comment returns the child node of ast.Field.Comment
+func (s *Field) Comment() *CommentGroup {
+	if s.Field.Comment == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.Field.Comment).(*CommentGroup)
+	return n
+}
+
+// Elt returns the child node of ast.Ellipsis.Elt
+func (s *Ellipsis) Elt() Node {
+	if s.Ellipsis.Elt == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.Ellipsis.Elt)
+}
+
+// Type returns the child node of ast.FuncLit.Type
+func (s *FuncLit) Type() *FuncType {
+	if s.FuncLit.Type == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.FuncLit.Type).(*FuncType)
+	return n
+}
+
+// Body returns the child node of ast.FuncLit.Body
+func (s *FuncLit) Body() *BlockStmt {
+	if s.FuncLit.Body == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.FuncLit.Body).(*BlockStmt)
+	return n
+}
+
+// Type returns the child node of ast.CompositeLit.Type
+func (s *CompositeLit) Type() Node {
+	if s.CompositeLit.Type == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.CompositeLit.Type)
+}
+
+// Elts returns the child nodes of ast.CompositeLit.Elts. The nodes have the same
+// indices as in the ast slice.
+func (s *CompositeLit) Elts() []Node {
+	if len(s.CompositeLit.Elts) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.CompositeLit.Elts))
+	for _, child := range s.CompositeLit.Elts {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// X returns the child node of ast.ParenExpr.X
+func (s *ParenExpr) X() Node {
+	if s.ParenExpr.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.ParenExpr.X)
+}
+
+// X returns the child node of ast.SelectorExpr.X
+func (s *SelectorExpr) X() Node {
+	if s.SelectorExpr.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.SelectorExpr.X)
+}
+
+// Sel returns the child node of ast.SelectorExpr.Sel
+func (s *SelectorExpr) Sel() *Ident {
+	if s.SelectorExpr.Sel == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.SelectorExpr.Sel).(*Ident)
+	return n
+}
+
+// X returns the child node of ast.IndexExpr.X
+func (s *IndexExpr) X() Node {
+	if s.IndexExpr.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.IndexExpr.X)
+}
+
+// Index returns the child node of ast.IndexExpr.Index
+func (s *IndexExpr) Index() Node {
+	if s.IndexExpr.Index == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.IndexExpr.Index)
+}
+
+// X returns the child node of ast.IndexListExpr.X
+func (s *IndexListExpr) X() Node {
+	if s.IndexListExpr.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.IndexListExpr.X)
+}
+
+// Indices returns the child nodes of ast.IndexListExpr.Indices. The nodes have the same
+// indices as in the ast slice.
+func (s *IndexListExpr) Indices() []Node {
+	if len(s.IndexListExpr.Indices) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.IndexListExpr.Indices))
+	for _, child := range s.IndexListExpr.Indices {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// X returns the child node of ast.SliceExpr.X
+func (s *SliceExpr) X() Node {
+	if s.SliceExpr.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.SliceExpr.X)
+}
+
+// Low returns the child node of ast.SliceExpr.Low
+func (s *SliceExpr) Low() Node {
+	if s.SliceExpr.Low == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.SliceExpr.Low)
+}
+
+// High returns the child node of ast.SliceExpr.High
+func (s *SliceExpr) High() Node {
+	if s.SliceExpr.High == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.SliceExpr.High)
+}
+
+// Max returns the child node of ast.SliceExpr.Max
+func (s *SliceExpr) Max() Node {
+	if s.SliceExpr.Max == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.SliceExpr.Max)
+}
+
+// X returns the child node of ast.TypeAssertExpr.X
+func (s *TypeAssertExpr) X() Node {
+	if s.TypeAssertExpr.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.TypeAssertExpr.X)
+}
+
+// Type returns the child node of ast.TypeAssertExpr.Type
+func (s *TypeAssertExpr) Type() Node {
+	if s.TypeAssertExpr.Type == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.TypeAssertExpr.Type)
+}
+
+// Fun returns the child node of ast.CallExpr.Fun
+func (s *CallExpr) Fun() Node {
+	if s.CallExpr.Fun == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.CallExpr.Fun)
+}
+
+// Args returns the child nodes of ast.CallExpr.Args. The nodes have the same
+// indices as in the ast slice.
+func (s *CallExpr) Args() []Node {
+	if len(s.CallExpr.Args) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.CallExpr.Args))
+	for _, child := range s.CallExpr.Args {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// X returns the child node of ast.StarExpr.X
+func (s *StarExpr) X() Node {
+	if s.StarExpr.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.StarExpr.X)
+}
+
+// X returns the child node of ast.UnaryExpr.X
+func (s *UnaryExpr) X() Node {
+	if s.UnaryExpr.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.UnaryExpr.X)
+}
+
+// X returns the child node of ast.BinaryExpr.X
+func (s *BinaryExpr) X() Node {
+	if s.BinaryExpr.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.BinaryExpr.X)
+}
+
+// Y returns the child node of ast.BinaryExpr.Y
+func (s *BinaryExpr) Y() Node {
+	if s.BinaryExpr.Y == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.BinaryExpr.Y)
+}
+
+// Key returns the child node of ast.KeyValueExpr.Key
+func (s *KeyValueExpr) Key() Node {
+	if s.KeyValueExpr.Key == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.KeyValueExpr.Key)
+}
+
+// Value returns the child node of ast.KeyValueExpr.Value
+func (s *KeyValueExpr) Value() Node {
+	if s.KeyValueExpr.Value == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.KeyValueExpr.Value)
+}
+
+// Len returns the child node of ast.ArrayType.Len
+func (s *ArrayType) Len() Node {
+	if s.ArrayType.Len == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.ArrayType.Len)
+}
+
+// Elt returns the child node of ast.ArrayType.Elt
+func (s *ArrayType) Elt() Node {
+	if s.ArrayType.Elt == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.ArrayType.Elt)
+}
+
+// Fields returns the child node of ast.StructType.Fields
+func (s *StructType) Fields() *FieldList {
+	if s.StructType.Fields == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.StructType.Fields).(*FieldList)
+	return n
+}
+
+// TypeParams returns the child node of ast.FuncType.TypeParams
+func (s *FuncType) TypeParams() *FieldList {
+	if s.FuncType.TypeParams == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.FuncType.TypeParams).(*FieldList)
+	return n
+}
+
+// Params returns the child node of ast.FuncType.Params
+func (s *FuncType) Params() *FieldList {
+	if s.FuncType.Params == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.FuncType.Params).(*FieldList)
+	return n
+}
+
+// Results returns the child node of ast.FuncType.Results
+func (s *FuncType) Results() *FieldList {
+	if s.FuncType.Results == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.FuncType.Results).(*FieldList)
+	return n
+}
+
+// Methods returns the child node of ast.InterfaceType.Methods
+func (s *InterfaceType) Methods() *FieldList {
+	if s.InterfaceType.Methods == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.InterfaceType.Methods).(*FieldList)
+	return n
+}
+
+// Key returns the child node of ast.MapType.Key
+func (s *MapType) Key() Node {
+	if s.MapType.Key == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.MapType.Key)
+}
+
+// Value returns the child node of ast.MapType.Value
+func (s *MapType) Value() Node {
+	if s.MapType.Value == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.MapType.Value)
+}
+
+// Value returns the child node of ast.ChanType.Value
+func (s *ChanType) Value() Node {
+	if s.ChanType.Value == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.ChanType.Value)
+}
+
+// Decl returns the child node of ast.DeclStmt.Decl
+func (s *DeclStmt) Decl() Node {
+	if s.DeclStmt.Decl == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.DeclStmt.Decl)
+}
+
+// Label returns the child node of ast.LabeledStmt.Label
+func (s *LabeledStmt) Label() *Ident {
+	if s.LabeledStmt.Label == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.LabeledStmt.Label).(*Ident)
+	return n
+}
+
+// Stmt returns the child node of ast.LabeledStmt.Stmt
+func (s *LabeledStmt) Stmt() Node {
+	if s.LabeledStmt.Stmt == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.LabeledStmt.Stmt)
+}
+
+// X returns the child node of ast.ExprStmt.X
+func (s *ExprStmt) X() Node {
+	if s.ExprStmt.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.ExprStmt.X)
+}
+
+// Chan returns the child node of ast.SendStmt.Chan
+func (s *SendStmt) Chan() Node {
+	if s.SendStmt.Chan == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.SendStmt.Chan)
+}
+
+// Value returns the child node of ast.SendStmt.Value
+func (s *SendStmt) Value() Node {
+	if s.SendStmt.Value == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.SendStmt.Value)
+}
+
+// X returns the child node of ast.IncDecStmt.X
+func (s *IncDecStmt) X() Node {
+	if s.IncDecStmt.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.IncDecStmt.X)
+}
+
+// LHS returns the child nodes of ast.AssignStmt.Lhs. The nodes have the same
+// indices as in the ast slice.
+func (s *AssignStmt) LHS() []Node {
+	if len(s.AssignStmt.Lhs) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.AssignStmt.Lhs))
+	for _, child := range s.AssignStmt.Lhs {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// RHS returns the child nodes of ast.AssignStmt.Rhs. The nodes have the same
+// indices as in the ast slice.
+func (s *AssignStmt) RHS() []Node {
+	if len(s.AssignStmt.Rhs) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.AssignStmt.Rhs))
+	for _, child := range s.AssignStmt.Rhs {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// Call returns the child node of ast.GoStmt.Call
+func (s *GoStmt) Call() *CallExpr {
+	if s.GoStmt.Call == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.GoStmt.Call).(*CallExpr)
+	return n
+}
+
+// Call returns the child node of ast.DeferStmt.Call
+func (s *DeferStmt) Call() *CallExpr {
+	if s.DeferStmt.Call == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.DeferStmt.Call).(*CallExpr)
+	return n
+}
+
+// Results returns the child nodes of ast.ReturnStmt.Results. The nodes have the same
+// indices as in the ast slice.
+func (s *ReturnStmt) Results() []Node {
+	if len(s.ReturnStmt.Results) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.ReturnStmt.Results))
+	for _, child := range s.ReturnStmt.Results {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// Label returns the child node of ast.BranchStmt.Label
+func (s *BranchStmt) Label() *Ident {
+	if s.BranchStmt.Label == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.BranchStmt.Label).(*Ident)
+	return n
+}
+
+// List returns the child nodes of ast.BlockStmt.List. The nodes have the same
+// indices as in the ast slice.
+func (s *BlockStmt) List() []Node {
+	if len(s.BlockStmt.List) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.BlockStmt.List))
+	for _, child := range s.BlockStmt.List {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// Init returns the child node of ast.IfStmt.Init
+func (s *IfStmt) Init() Node {
+	if s.IfStmt.Init == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.IfStmt.Init)
+}
+
+// Cond returns the child node of ast.IfStmt.Cond
+func (s *IfStmt) Cond() Node {
+	if s.IfStmt.Cond == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.IfStmt.Cond)
+}
+
+// Body returns the child node of ast.IfStmt.Body. See BodyBlock for the typed node.
+func (s *IfStmt) Body() Node {
+	if s.IfStmt.Body == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.IfStmt.Body)
+}
+
+// BodyBlock returns the child node of ast.IfStmt.Body
+func (s *IfStmt) BodyBlock() *BlockStmt {
+	if s.IfStmt.Body == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.IfStmt.Body).(*BlockStmt)
+	return n
+}
+
+// Else returns the child node of ast.IfStmt.Else
+func (s *IfStmt) Else() Node {
+	if s.IfStmt.Else == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.IfStmt.Else)
+}
+
+// List returns the child nodes of ast.CaseClause.List. The nodes have the same
+// indices as in the ast slice.
+func (s *CaseClause) List() []Node {
+	if len(s.CaseClause.List) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.CaseClause.List))
+	for _, child := range s.CaseClause.List {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// Body returns the child nodes of ast.CaseClause.Body. The nodes have the same
+// indices as in the ast slice.
+func (s *CaseClause) Body() []Node {
+	if len(s.CaseClause.Body) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.CaseClause.Body))
+	for _, child := range s.CaseClause.Body {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// Init returns the child node of ast.SwitchStmt.Init
+func (s *SwitchStmt) Init() Node {
+	if s.SwitchStmt.Init == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.SwitchStmt.Init)
+}
+
+// Tag returns the child node of ast.SwitchStmt.Tag
+func (s *SwitchStmt) Tag() Node {
+	if s.SwitchStmt.Tag == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.SwitchStmt.Tag)
+}
+
+// Body returns the child node of ast.SwitchStmt.Body
+func (s *SwitchStmt) Body() *BlockStmt {
+	if s.SwitchStmt.Body == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.SwitchStmt.Body).(*BlockStmt)
+	return n
+}
+
+// Init returns the child node of ast.TypeSwitchStmt.Init
+func (s *TypeSwitchStmt) Init() Node {
+	if s.TypeSwitchStmt.Init == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.TypeSwitchStmt.Init)
+}
+
+// Assign returns the child node of ast.TypeSwitchStmt.Assign
+func (s *TypeSwitchStmt) Assign() Node {
+	if s.TypeSwitchStmt.Assign == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.TypeSwitchStmt.Assign)
+}
+
+// Body returns the child node of ast.TypeSwitchStmt.Body
+func (s *TypeSwitchStmt) Body() *BlockStmt {
+	if s.TypeSwitchStmt.Body == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.TypeSwitchStmt.Body).(*BlockStmt)
+	return n
+}
+
+// Comm returns the child node of ast.CommClause.Comm
+func (s *CommClause) Comm() Node {
+	if s.CommClause.Comm == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.CommClause.Comm)
+}
+
+// Body returns the child nodes of ast.CommClause.Body. The nodes have the same
+// indices as in the ast slice.
+func (s *CommClause) Body() []Node {
+	if len(s.CommClause.Body) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.CommClause.Body))
+	for _, child := range s.CommClause.Body {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// Body returns the child node of ast.SelectStmt.Body
+func (s *SelectStmt) Body() *BlockStmt {
+	if s.SelectStmt.Body == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.SelectStmt.Body).(*BlockStmt)
+	return n
+}
+
+// Init returns the child node of ast.ForStmt.Init
+func (s *ForStmt) Init() Node {
+	if s.ForStmt.Init == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.ForStmt.Init)
+}
+
+// Cond returns the child node of ast.ForStmt.Cond
+func (s *ForStmt) Cond() Node {
+	if s.ForStmt.Cond == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.ForStmt.Cond)
+}
+
+// Post returns the child node of ast.ForStmt.Post
+func (s *ForStmt) Post() Node {
+	if s.ForStmt.Post == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.ForStmt.Post)
+}
+
+// Body returns the child node of ast.ForStmt.Body
+func (s *ForStmt) Body() *BlockStmt {
+	if s.ForStmt.Body == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.ForStmt.Body).(*BlockStmt)
+	return n
+}
+
+// Key returns the child node of ast.RangeStmt.Key
+func (s *RangeStmt) Key() Node {
+	if s.RangeStmt.Key == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.RangeStmt.Key)
+}
+
+// Value returns the child node of ast.RangeStmt.Value
+func (s *RangeStmt) Value() Node {
+	if s.RangeStmt.Value == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.RangeStmt.Value)
+}
+
+// X returns the child node of ast.RangeStmt.X
+func (s *RangeStmt) X() Node {
+	if s.RangeStmt.X == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.RangeStmt.X)
+}
+
+// Body returns the child node of ast.RangeStmt.Body
+func (s *RangeStmt) Body() *BlockStmt {
+	if s.RangeStmt.Body == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.RangeStmt.Body).(*BlockStmt)
+	return n
+}
+
+// Doc returns the child node of ast.ImportSpec.Doc
+func (s *ImportSpec) Doc() *CommentGroup {
+	if s.ImportSpec.Doc == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.ImportSpec.Doc).(*CommentGroup)
+	return n
+}
+
+// Path returns the child node of ast.ImportSpec.Path
+func (s *ImportSpec) Path() *BasicLit {
+	if s.ImportSpec.Path == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.ImportSpec.Path).(*BasicLit)
+	return n
+}
+
+// Comment returns the child node of ast.ImportSpec.Comment
+func (s *ImportSpec) Comment() *CommentGroup {
+	if s.ImportSpec.Comment == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.ImportSpec.Comment).(*CommentGroup)
+	return n
+}
+
+// Doc returns the child node of ast.ValueSpec.Doc
+func (s *ValueSpec) Doc() *CommentGroup {
+	if s.ValueSpec.Doc == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.ValueSpec.Doc).(*CommentGroup)
+	return n
+}
+
+// Names returns the child nodes of ast.ValueSpec.Names. The nodes have the same
+// indices as in the ast slice.
+func (s *ValueSpec) Names() []*Ident {
+	if len(s.ValueSpec.Names) == 0 {
+		return nil
+	}
+	nodes := make([]*Ident, 0, len(s.ValueSpec.Names))
+	for _, child := range s.ValueSpec.Names {
+		n, _ := s.findChildByAstNode(child).(*Ident)
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// Type returns the child node of ast.ValueSpec.Type
+func (s *ValueSpec) Type() Node {
+	if s.ValueSpec.Type == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.ValueSpec.Type)
+}
+
+// Values returns the child nodes of ast.ValueSpec.Values. The nodes have the same
+// indices as in the ast slice.
+func (s *ValueSpec) Values() []Node {
+	if len(s.ValueSpec.Values) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.ValueSpec.Values))
+	for _, child := range s.ValueSpec.Values {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// Comment returns the child node of ast.ValueSpec.Comment
+func (s *ValueSpec) Comment() *CommentGroup {
+	if s.ValueSpec.Comment == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.ValueSpec.Comment).(*CommentGroup)
+	return n
+}
+
+// Doc returns the child node of ast.TypeSpec.Doc
+func (s *TypeSpec) Doc() *CommentGroup {
+	if s.TypeSpec.Doc == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.TypeSpec.Doc).(*CommentGroup)
+	return n
+}
+
+// TypeParams returns the child node of ast.TypeSpec.TypeParams
+func (s *TypeSpec) TypeParams() *FieldList {
+	if s.TypeSpec.TypeParams == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.TypeSpec.TypeParams).(*FieldList)
+	return n
+}
+
+// Type returns the child node of ast.TypeSpec.Type
+func (s *TypeSpec) Type() Node {
+	if s.TypeSpec.Type == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.TypeSpec.Type)
+}
+
+// Comment returns the child node of ast.TypeSpec.Comment
+func (s *TypeSpec) Comment() *CommentGroup {
+	if s.TypeSpec.Comment == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.TypeSpec.Comment).(*CommentGroup)
+	return n
+}
+
+// Doc returns the child node of ast.GenDecl.Doc
+func (s *GenDecl) Doc() *CommentGroup {
+	if s.GenDecl.Doc == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.GenDecl.Doc).(*CommentGroup)
+	return n
+}
+
+// Specs returns the child nodes of ast.GenDecl.Specs. The nodes have the same
+// indices as in the ast slice.
+func (s *GenDecl) Specs() []Node {
+	if len(s.GenDecl.Specs) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.GenDecl.Specs))
+	for _, child := range s.GenDecl.Specs {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
+
+// Doc returns the child node of ast.FuncDecl.Doc
+func (s *FuncDecl) Doc() *CommentGroup {
+	if s.FuncDecl.Doc == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.FuncDecl.Doc).(*CommentGroup)
+	return n
+}
+
+// Recv returns the child node of ast.FuncDecl.Recv
+func (s *FuncDecl) Recv() *FieldList {
+	if s.FuncDecl.Recv == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.FuncDecl.Recv).(*FieldList)
+	return n
+}
+
+// Type returns the child node of ast.FuncDecl.Type
+func (s *FuncDecl) Type() *FuncType {
+	if s.FuncDecl.Type == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.FuncDecl.Type).(*FuncType)
+	return n
+}
+
+// Body returns the child node of ast.FuncDecl.Body
+func (s *FuncDecl) Body() *BlockStmt {
+	if s.FuncDecl.Body == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.FuncDecl.Body).(*BlockStmt)
+	return n
+}
+
+// Doc returns the child node of ast.File.Doc
+func (s *File) Doc() *CommentGroup {
+	if s.File.Doc == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.File.Doc).(*CommentGroup)
+	return n
+}
+
+// Decls returns the child nodes of ast.File.Decls. The nodes have the same
+// indices as in the ast slice.
+func (s *File) Decls() []Node {
+	if len(s.File.Decls) == 0 {
+		return nil
+	}
+	nodes := make([]Node, 0, len(s.File.Decls))
+	for _, child := range s.File.Decls {
+		nodes = append(nodes, s.findChildByAstNode(child))
+	}
+	return nodes
+}
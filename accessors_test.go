@@ -0,0 +1,108 @@
+package astrav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseClause_List(t *testing.T) {
+	n := getPackage(t, 1)
+
+	clauses := n.FindByNodeType(NodeTypeCaseClause)
+	assert.Equal(t, 8, len(clauses))
+
+	list := clauses[1].(*CaseClause).List()
+	assert.Equal(t, 2, len(list))
+	assert.Equal(t, `"d"`, list[0].(*BasicLit).Value)
+	assert.Equal(t, 1, len(clauses[1].(*CaseClause).Body()))
+	assert.Equal(t, 0, len(clauses[7].(*CaseClause).List()))
+}
+
+func TestSwitchStmt_Tag(t *testing.T) {
+	n := getPackage(t, 1)
+
+	sw := n.FindFirstByNodeType(NodeTypeSwitchStmt).(*SwitchStmt)
+	assert.Nil(t, sw.Init())
+	assert.Equal(t, NodeTypeCallExpr, sw.Tag().NodeType())
+	assert.Equal(t, 8, len(sw.Body().List()))
+}
+
+func TestReturnStmt_Results(t *testing.T) {
+	n := getPackage(t, 7)
+
+	ret := n.FindFirstByNodeType(NodeTypeReturnStmt).(*ReturnStmt)
+	results := ret.Results()
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, NodeTypeBasicLit, results[0].NodeType())
+	assert.Equal(t, ret, results[0].Parent())
+}
+
+func TestCompositeLit_Elts(t *testing.T) {
+	n := getPackage(t, 8)
+
+	lit := n.FindFirstByNodeType(NodeTypeCompositeLit).(*CompositeLit)
+	mapType := lit.Type().(*MapType)
+	assert.Equal(t, "string", mapType.Key().(*Ident).Name)
+	assert.Equal(t, "int", mapType.Value().(*Ident).Name)
+
+	elts := lit.Elts()
+	assert.Equal(t, 7, len(elts))
+	kv := elts[6].(*KeyValueExpr)
+	assert.Equal(t, `"QZqz"`, kv.Key().(*BasicLit).Value)
+	assert.Equal(t, "10", kv.Value().(*BasicLit).Value)
+}
+
+func TestGenDecl_Specs(t *testing.T) {
+	n := getPackage(t, 7)
+
+	decl := n.FindFirstByNodeType(NodeTypeDeclStmt).(*DeclStmt).Decl().(*GenDecl)
+	specs := decl.Specs()
+	assert.Equal(t, 1, len(specs))
+
+	spec := specs[0].(*ValueSpec)
+	names := spec.Names()
+	assert.Equal(t, 2, len(names))
+	assert.Equal(t, "lenA", names[0].Name)
+	assert.Equal(t, "int", spec.Type().(*Ident).Name)
+	assert.Equal(t, 0, len(spec.Values()))
+}
+
+func TestFieldList_Fields(t *testing.T) {
+	n := getPackage(t, 7)
+
+	params := n.(*Package).FuncDeclByName("Distance").Params()
+	assert.Equal(t, 1, len(params.List))
+
+	fields := params.Fields()
+	assert.Equal(t, 2, len(fields))
+	assert.Equal(t, "a", fields[0].Names()[0].Name)
+	assert.Equal(t, "b", fields[1].Names()[0].Name)
+	assert.Equal(t, "string", fields[1].Type().(*Ident).Name)
+}
+
+func TestIfStmt_Body(t *testing.T) {
+	n := getPackage(t, 6)
+
+	ifStmt := n.FindFirstByNodeType(NodeTypeIfStmt).(*IfStmt)
+	assert.Equal(t, NodeTypeBlockStmt, ifStmt.Body().NodeType())
+	body := ifStmt.BodyBlock()
+	assert.Equal(t, ifStmt.Body(), body)
+	assert.Equal(t, 1, len(body.List()))
+	assert.Equal(t, NodeTypeAssignStmt, body.List()[0].NodeType())
+	assert.True(t, ifStmt.Else() == nil)
+}
+
+func TestCallExpr_Args(t *testing.T) {
+	n := getPackage(t, 1)
+
+	for _, node := range n.FindByNodeType(NodeTypeCallExpr) {
+		call := node.(*CallExpr)
+		args := call.Args()
+		if assert.Equal(t, len(call.CallExpr.Args), len(args)) {
+			for i, arg := range args {
+				assert.Equal(t, call.CallExpr.Args[i], arg.AstNode())
+			}
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Package astravtest provides utilities for testing rules, similar to
+// golang.org/x/tools/go/analysis/analysistest.
+//
+// Expected diagnostics are written as comments in the source of the test data:
+//
+//	fmt.Println(x) // want "do not print to stdout"
+//
+// A want comment contains one or more Go string literals. Each is a regular expression that must
+// match the message of a diagnostic reported on the same line. Diagnostics that are not expected
+// and expectations without diagnostic both fail the test. Diagnostics without a position (e.g.
+// from must-exist rules missing their function) are matched on the line of the package clause.
+package astravtest
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/scanner"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+// TestingT is the subset of testing.TB used by the package.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+type helper interface {
+	Helper()
+}
+
+type expectation struct {
+	rx      *regexp.Regexp
+	matched bool
+}
+
+type lineKey struct {
+	file string
+	line int
+}
+
+// Run loads the package in dir, checks it with the rule set and compares the diagnostics with the
+// want comments of the source files. The diagnostics are returned for further inspection.
+func Run(t TestingT, dir string, set *rules.RuleSet) []*rules.Diagnostic {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	pkg := LoadPackage(t, dir)
+	if pkg == nil {
+		return nil
+	}
+
+	diags := set.Check(pkg)
+	Check(t, pkg, diags)
+	return diags
+}
+
+// RunRule is a convenience function for Run with a single rule.
+func RunRule(t TestingT, dir string, rule *rules.Rule) []*rules.Diagnostic {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Run(t, dir, &rules.RuleSet{Rules: []*rules.Rule{rule}})
+}
+
+// LoadPackage loads the package in given directory. Test files are skipped.
+func LoadPackage(t TestingT, dir string) *astrav.Package {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	folder := astrav.NewFolder(http.Dir(dir), "")
+	pkgs, err := folder.ParseFolder()
+	if err != nil {
+		t.Fatalf("loading %s: %v", dir, err)
+		return nil
+	}
+	if len(pkgs) != 1 {
+		t.Fatalf("loading %s: expected exactly one package, got %d", dir, len(pkgs))
+		return nil
+	}
+
+	for _, pkg := range pkgs {
+		return pkg
+	}
+	return nil
+}
+
+// Golden compares the formatted diagnostics with the content of the golden file. If update is true,
+// the golden file is written instead. Tests typically pass the value of their own -update flag:
+//
+//	var update = flag.Bool("update", false, "update golden files")
+//
+//	astravtest.Golden(t, "testdata/x.golden", diags, *update)
+func Golden(t TestingT, goldenFile string, diags []*rules.Diagnostic, update bool) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	var buf bytes.Buffer
+	for _, diag := range diags {
+		buf.WriteString(diag.String())
+		buf.WriteByte('\n')
+	}
+
+	if update {
+		if err := ioutil.WriteFile(goldenFile, buf.Bytes(), 0644); err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+		return
+	}
+	if !bytes.Equal(want, buf.Bytes()) {
+		t.Errorf("diagnostics differ from golden file %s:\n--- want\n%s--- got\n%s", goldenFile, want, buf.Bytes())
+	}
+}
+
+// Check compares the diagnostics with the want comments of the source files of the package.
+// It can be used to test diagnostics that are not produced by a rule set.
+func Check(t TestingT, pkg *astrav.Package, diags []*rules.Diagnostic) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	want, pkgLine := expectations(t, pkg)
+
+	for _, diag := range diags {
+		key := pkgLine
+		if diag.Pos.IsValid() {
+			key = lineKey{file: diag.Pos.Filename, line: diag.Pos.Line}
+		}
+
+		var found bool
+		for _, exp := range want[key] {
+			if !exp.matched && exp.rx.MatchString(diag.Message) {
+				exp.matched = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%s:%d: unexpected diagnostic: %s", key.file, key.line, diag.Message)
+		}
+	}
+
+	keys := make([]lineKey, 0, len(want))
+	for key := range want {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].file != keys[j].file {
+			return keys[i].file < keys[j].file
+		}
+		return keys[i].line < keys[j].line
+	})
+	for _, key := range keys {
+		for _, exp := range want[key] {
+			if !exp.matched {
+				t.Errorf("%s:%d: no diagnostic was reported matching %q", key.file, key.line, exp.rx)
+			}
+		}
+	}
+}
+
+// expectations collects the want comments of all files in the package. It also returns the
+// location of the first package clause to match diagnostics without position.
+func expectations(t TestingT, pkg *astrav.Package) (map[lineKey][]*expectation, lineKey) {
+	var (
+		want    = map[lineKey][]*expectation{}
+		pkgLine lineKey
+	)
+
+	files := pkg.FindByNodeType(astrav.NodeTypeFile)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Position().Filename < files[j].Position().Filename
+	})
+
+	for _, node := range files {
+		file := node.(*astrav.File)
+		if pkgLine.file == "" {
+			pos := file.GetIdent().Position()
+			pkgLine = lineKey{file: pos.Filename, line: pos.Line}
+		}
+
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				text := strings.TrimPrefix(comment.Text, "//")
+				text = strings.TrimSpace(text)
+				if !strings.HasPrefix(text, "want ") {
+					continue
+				}
+
+				key := lineKey{file: file.Position().Filename, line: lineOf(file, comment.Slash)}
+
+				patterns, err := parsePatterns(strings.TrimPrefix(text, "want "))
+				if err != nil {
+					t.Errorf("%s:%d: invalid want comment: %v", key.file, key.line, err)
+					continue
+				}
+				for _, rx := range patterns {
+					want[key] = append(want[key], &expectation{rx: rx})
+				}
+			}
+		}
+	}
+	return want, pkgLine
+}
+
+// lineOf calculates the line of a position within the file. Comments outside of nodes
+// (e.g. trailing line comments) are not part of the tree and have to be resolved by hand.
+func lineOf(file *astrav.File, pos token.Pos) int {
+	offset := file.Position().Offset + int(pos-file.Pos())
+	return bytes.Count(file.GetSource()[:offset], []byte{'\n'}) + 1
+}
+
+// parsePatterns parses a sequence of Go string literals into regular expressions.
+func parsePatterns(text string) ([]*regexp.Regexp, error) {
+	var (
+		s        scanner.Scanner
+		patterns []*regexp.Regexp
+	)
+	s.Init(strings.NewReader(text))
+	s.Mode = scanner.ScanStrings | scanner.ScanRawStrings
+	s.Error = func(*scanner.Scanner, string) {}
+
+	for tok := s.Scan(); tok != scanner.EOF; tok = s.Scan() {
+		if tok != scanner.String && tok != scanner.RawString {
+			return nil, fmt.Errorf("expected string literal, got %s", s.TokenText())
+		}
+
+		lit, err := strconv.Unquote(s.TokenText())
+		if err != nil {
+			return nil, err
+		}
+		rx, err := regexp.Compile(lit)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, rx)
+	}
+
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no pattern given")
+	}
+	return patterns, nil
+}
@@ -0,0 +1,85 @@
+package astravtest
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type recorder struct {
+	errors []string
+}
+
+func (s *recorder) Errorf(format string, args ...interface{}) {
+	s.errors = append(s.errors, fmt.Sprintf(format, args...))
+}
+
+func (s *recorder) Fatalf(format string, args ...interface{}) {
+	s.Errorf(format, args...)
+}
+
+func TestRun(t *testing.T) {
+	set, err := rules.Load("testdata/hamming/rules.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := Run(t, "testdata/hamming", set)
+	assert.Equal(t, 4, len(diags))
+
+	Golden(t, "testdata/hamming/hamming.golden", diags, *update)
+}
+
+func TestRun_Mismatch(t *testing.T) {
+	set, err := rules.Load("testdata/hamming/rules.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set.Rules = set.Rules[1:2]
+
+	rec := &recorder{}
+	Run(rec, "testdata/hamming", set)
+	assert.Equal(t, []string{
+		`hamming.go:9: no diagnostic was reported matching "Distance should return a custom error"`,
+		`hamming.go:17: no diagnostic was reported matching "counting inside a loop"`,
+		"hamming.go:21: no diagnostic was reported matching \"to stdout$\"",
+	}, rec.errors)
+
+	set.Rules[0].Message = "unexpected"
+	rec = &recorder{}
+	RunRule(rec, "testdata/hamming", set.Rules[0])
+	assert.Contains(t, rec.errors, "hamming.go:21: unexpected diagnostic: unexpected")
+}
+
+func TestRun_PackageLevel(t *testing.T) {
+	set, err := rules.Load("testdata/hamming/rules.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set.Rules = set.Rules[:1]
+	set.Rules[0].CallTreeOf = "Missing"
+
+	rec := &recorder{}
+	Run(rec, "testdata/hamming", set)
+	assert.Equal(t, "hamming.go:1: unexpected diagnostic: Distance should return a custom error", rec.errors[0])
+	assert.Equal(t, `hamming.go:9: no diagnostic was reported matching "Distance should return a custom error"`,
+		rec.errors[1])
+}
+
+func TestParsePatterns(t *testing.T) {
+	patterns, err := parsePatterns("\"a b\" `c\\.d`")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(patterns))
+	assert.Equal(t, "a b", patterns[0].String())
+	assert.Equal(t, `c\.d`, patterns[1].String())
+
+	_, err = parsePatterns("abc")
+	assert.Error(t, err)
+	_, err = parsePatterns("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,23 @@
+package hamming
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Distance calculates the hamming distance of two strings.
+func Distance(a, b string) (int, error) { // want "Distance should return a custom error"
+	if len(a) != len(b) {
+		return 0, errors.New("different length")
+	}
+
+	var diff int
+	for i := range a {
+		if a[i] != b[i] {
+			diff++ // want "counting inside a loop"
+		}
+	}
+
+	fmt.Println(diff) // want "do not print" `to stdout$`
+	return diff, nil
+}
@@ -0,0 +1,624 @@
+package astrav
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/gcexportdata"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+)
+
+const (
+	cacheIndexFile  = "index.gob"
+	cacheExportFile = "deps.export"
+	// cacheVersion is part of the cache key. It changes whenever the content of the cache changes.
+	cacheVersion = "2"
+)
+
+// cacheEnv lists the go env variables influencing how the go command resolves and builds packages.
+var cacheEnv = []string{"GOVERSION", "GOROOT", "GOOS", "GOARCH", "CGO_ENABLED", "GOFLAGS", "GOEXPERIMENT", "GOWORK"}
+
+// cacheIndex describes the packages of a module and their call graph. The types of all dependencies are
+// stored as export data next to it.
+type cacheIndex struct {
+	// Packages lists the packages of the module ordered so that every package comes after the packages it imports.
+	Packages []cachedPackage
+	// Graph is the call graph of the module and its dependencies.
+	Graph *cachedGraph
+}
+
+type cachedPackage struct {
+	ID        string
+	PkgPath   string
+	Name      string
+	GoVersion string
+	// Files are the compiled Go files of the package.
+	Files []string
+	// Imports maps the import paths to the package paths of the imported packages.
+	Imports map[string]string
+}
+
+// cachedGraph is a call graph that can be restored for another SSA program of the same packages.
+type cachedGraph struct {
+	// Funcs indexes the functions of the call graph.
+	Funcs []cachedFunc
+	Edges []cachedEdge
+}
+
+// cachedFunc identifies a function of the call graph. Functions declared in a package and methods of named
+// types are found by their declaration, all other functions by their name.
+type cachedFunc struct {
+	// Name is the name of the function as returned by ssa.Function.String.
+	Name string
+	// Pkg is the path of the package declaring the function or method.
+	Pkg string
+	// Recv is the receiver type of a method qualified with its package path and prefixed with * for
+	// pointer receivers.
+	Recv string
+	// Decl is the declared name of the function or method.
+	Decl string
+}
+
+// cachedEdge is a call from Caller to Callee, both indices of cachedGraph.Funcs. Site is the index of the
+// call instruction within the caller or -1 if it is unknown.
+type cachedEdge struct {
+	Caller, Callee, Site int
+}
+
+// useCache checks if loading the module can use the cache.
+func (s *Module) useCache() bool {
+	return s.CacheDir != "" && len(s.Dependencies) == 0
+}
+
+// cacheKey hashes everything the result of loading the module depends on: the go env, build tags,
+// go.work, go.mod and go.sum files, the content of all Go files of the module and of local replace targets.
+// Dependencies of the module cache and the standard library are identified by go.sum and the Go version.
+func (s *Module) cacheKey(fileSources map[string][]byte) (string, error) {
+	hash := sha256.New()
+	write := func(parts ...string) {
+		for _, part := range parts {
+			hash.Write([]byte(part))
+			hash.Write([]byte{0})
+		}
+	}
+
+	goEnv, err := s.goEnv(cacheEnv...)
+	if err != nil {
+		return "", err
+	}
+	write(cacheVersion)
+	for _, name := range cacheEnv {
+		write(name, goEnv[name])
+	}
+	write(strings.Join(build.Default.BuildTags, ","))
+
+	var replaces []string
+	if s.workFile != "" {
+		for _, name := range []string{s.workFile, s.workFile + ".sum"} {
+			bts, _ := os.ReadFile(name)
+			write(name, string(bts))
+		}
+		replaces = append(replaces, localReplaces(s.workFile)...)
+	}
+	for _, dir := range s.Dirs() {
+		modDir := findModDir(dir)
+		if modDir == "" {
+			continue
+		}
+		for _, name := range []string{"go.mod", "go.sum"} {
+			bts, _ := os.ReadFile(filepath.Join(modDir, name))
+			write(filepath.Join(modDir, name), string(bts))
+		}
+		replaces = append(replaces, localReplaces(filepath.Join(modDir, "go.mod"))...)
+	}
+
+	sources := make(map[string][]byte, len(fileSources))
+	for name, src := range fileSources {
+		sources[name] = src
+	}
+	for _, dir := range replaces {
+		if r := readModuleFiles(dir, sources); r != nil {
+			return "", r
+		}
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sum := sha256.Sum256(sources[name])
+		write(name, hex.EncodeToString(sum[:]))
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+var (
+	goEnvMu    sync.Mutex
+	goEnvCache = map[string]map[string]string{}
+)
+
+// goEnv returns the values of the given go env variables as reported by the go command in the module directory.
+// The values are cached per process for the directory and the environment of the process. Changes made with
+// go env -w while the process is running are not seen.
+func (s *Module) goEnv(names ...string) (map[string]string, error) {
+	key := strings.Join(append(append([]string{s.dir}, names...), os.Environ()...), "\x00")
+
+	goEnvMu.Lock()
+	defer goEnvMu.Unlock()
+	if env, ok := goEnvCache[key]; ok {
+		return env, nil
+	}
+
+	cmd := exec.Command("go", append([]string{"env", "-json"}, names...)...)
+	cmd.Dir = s.dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed reading go env")
+	}
+	env := map[string]string{}
+	if r := json.Unmarshal(out, &env); r != nil {
+		return nil, errors.WithMessage(r, "failed reading go env")
+	}
+	goEnvCache[key] = env
+	return env, nil
+}
+
+// localReplaces returns the directories of all replace directives of the go.mod or go.work file pointing
+// to a local directory.
+func localReplaces(modFile string) []string {
+	bts, err := os.ReadFile(modFile)
+	if err != nil {
+		return nil
+	}
+
+	var replaces []*modfile.Replace
+	if strings.HasSuffix(modFile, ".work") {
+		work, err := modfile.ParseWork(modFile, bts, nil)
+		if err != nil {
+			return nil
+		}
+		replaces = work.Replace
+	} else {
+		mod, err := modfile.Parse(modFile, bts, nil)
+		if err != nil {
+			return nil
+		}
+		replaces = mod.Replace
+	}
+
+	var dirs []string
+	for _, replace := range replaces {
+		if replace.New.Version != "" || !modfile.IsDirectoryPath(replace.New.Path) {
+			continue
+		}
+		dir := replace.New.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(modFile), dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// readModuleFiles adds the Go files and module files within dir to sources. Directories ignored by the
+// go command are skipped.
+func readModuleFiles(dir string, sources map[string][]byte) error {
+	err := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); filePath != dir && (name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".go") && d.Name() != "go.mod" && d.Name() != "go.sum" {
+			return nil
+		}
+		if _, ok := sources[filePath]; ok {
+			return nil
+		}
+		bts, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		sources[filePath] = bts
+		return nil
+	})
+	if err != nil {
+		return errors.WithMessagef(err, "failed reading replaced module %s", dir)
+	}
+	return nil
+}
+
+func findModDir(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// loadCached loads the packages of the module from the cache without running the go command. The types of all
+// dependencies are read from export data, only the packages of the module are parsed and type-checked. The
+// cached call graph is returned to be restored once the SSA program is built. Nil is returned if the cache is
+// disabled or does not contain an entry for the current state of the module.
+func (s *Module) loadCached(key string) (*cachedGraph, error) {
+	if !s.useCache() {
+		return nil, nil
+	}
+	dir := filepath.Join(s.CacheDir, key)
+
+	indexData, err := os.ReadFile(filepath.Join(dir, cacheIndexFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed reading cache %s", dir)
+	}
+	var index cacheIndex
+	if r := gob.NewDecoder(bytes.NewReader(indexData)).Decode(&index); r != nil {
+		return nil, errors.WithMessagef(r, "invalid cache index in %s", dir)
+	}
+	exportData, err := os.ReadFile(filepath.Join(dir, cacheExportFile))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed reading cache %s", dir)
+	}
+
+	s.FSet = token.NewFileSet()
+	imports := map[string]*types.Package{}
+	if _, r := gcexportdata.ReadBundle(bytes.NewReader(exportData), s.FSet, imports); r != nil {
+		return nil, errors.WithMessagef(r, "failed reading export data from %s", dir)
+	}
+
+	sizes := types.SizesFor("gc", build.Default.GOARCH)
+	loaded := make(map[string]*packages.Package, len(imports)+len(index.Packages))
+	var depOf func(pkg *types.Package) *packages.Package
+	depOf = func(pkg *types.Package) *packages.Package {
+		if dep, ok := loaded[pkg.Path()]; ok {
+			return dep
+		}
+		dep := &packages.Package{
+			ID:         pkg.Path(),
+			PkgPath:    pkg.Path(),
+			Name:       pkg.Name(),
+			Imports:    make(map[string]*packages.Package, len(pkg.Imports())),
+			Types:      pkg,
+			Fset:       s.FSet,
+			TypesSizes: sizes,
+		}
+		loaded[pkg.Path()] = dep
+		for _, imp := range pkg.Imports() {
+			dep.Imports[imp.Path()] = depOf(imp)
+		}
+		return dep
+	}
+	depOf(types.Unsafe)
+	for _, pkg := range imports {
+		depOf(pkg)
+	}
+
+	packs := make([]*packages.Package, 0, len(index.Packages))
+	for _, cached := range index.Packages {
+		pack := &packages.Package{
+			ID:              cached.ID,
+			PkgPath:         cached.PkgPath,
+			Name:            cached.Name,
+			GoFiles:         cached.Files,
+			CompiledGoFiles: cached.Files,
+			Imports:         make(map[string]*packages.Package, len(cached.Imports)),
+			Fset:            s.FSet,
+			TypesSizes:      sizes,
+		}
+		if cached.GoVersion != "" {
+			pack.Module = &packages.Module{GoVersion: cached.GoVersion}
+		}
+		for importPath, pkgPath := range cached.Imports {
+			imp, ok := loaded[pkgPath]
+			if !ok {
+				return nil, errors.Errorf("cache %s misses package %s", dir, pkgPath)
+			}
+			pack.Imports[importPath] = imp
+		}
+
+		for _, fileName := range cached.Files {
+			file, err := parser.ParseFile(s.FSet, fileName, nil, parser.AllErrors|parser.ParseComments)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed parsing %s", fileName)
+			}
+			pack.Syntax = append(pack.Syntax, file)
+		}
+		s.typeCheck(pack)
+
+		loaded[pack.PkgPath] = pack
+		packs = append(packs, pack)
+	}
+
+	s.Packages = packs
+	s.exportedDeps = true
+	return index.Graph, nil
+}
+
+// storeCache writes the export data of all dependencies, the layout of the module packages and the call graph
+// to the cache. Modules with errors or cgo files are not cached.
+func (s *Module) storeCache(key string) error {
+	if !s.useCache() {
+		return nil
+	}
+
+	var (
+		index     cacheIndex
+		deps      []*types.Package
+		hasErrors bool
+		roots     = make(map[*packages.Package]bool, len(s.Packages))
+	)
+	for _, pack := range s.Packages {
+		if len(pack.CompiledGoFiles) != len(pack.GoFiles) {
+			return nil
+		}
+		roots[pack] = true
+	}
+	packages.Visit(s.Packages, nil, func(pack *packages.Package) {
+		hasErrors = hasErrors || len(pack.Errors) != 0
+		if !roots[pack] {
+			if pack.Types != nil && pack.Types != types.Unsafe {
+				deps = append(deps, pack.Types)
+			}
+			return
+		}
+
+		cached := cachedPackage{
+			ID:      pack.ID,
+			PkgPath: pack.PkgPath,
+			Name:    pack.Name,
+			Files:   pack.CompiledGoFiles,
+			Imports: make(map[string]string, len(pack.Imports)),
+		}
+		if pack.Module != nil {
+			cached.GoVersion = pack.Module.GoVersion
+		}
+		for importPath, imp := range pack.Imports {
+			cached.Imports[importPath] = imp.PkgPath
+		}
+		index.Packages = append(index.Packages, cached)
+	})
+	if hasErrors {
+		return nil
+	}
+	index.Graph = s.encodeCallGraph()
+
+	var exportData bytes.Buffer
+	if r := gcexportdata.WriteBundle(&exportData, s.FSet, deps); r != nil {
+		return errors.WithMessage(r, "failed writing export data")
+	}
+	var indexData bytes.Buffer
+	if r := gob.NewEncoder(&indexData).Encode(index); r != nil {
+		return errors.WithMessage(r, "failed encoding cache index")
+	}
+
+	if r := os.MkdirAll(s.CacheDir, 0o755); r != nil {
+		return errors.WithMessagef(r, "failed creating cache %s", s.CacheDir)
+	}
+	tmpDir, err := os.MkdirTemp(s.CacheDir, key+".tmp")
+	if err != nil {
+		return errors.WithMessagef(err, "failed creating cache %s", s.CacheDir)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if r := os.WriteFile(filepath.Join(tmpDir, cacheExportFile), exportData.Bytes(), 0o644); r != nil {
+		return errors.WithMessage(r, "failed writing cache")
+	}
+	if r := os.WriteFile(filepath.Join(tmpDir, cacheIndexFile), indexData.Bytes(), 0o644); r != nil {
+		return errors.WithMessage(r, "failed writing cache")
+	}
+	if r := os.Rename(tmpDir, filepath.Join(s.CacheDir, key)); r != nil {
+		// another load might have stored the same entry in the meantime
+		if _, statErr := os.Stat(filepath.Join(s.CacheDir, key, cacheIndexFile)); statErr != nil {
+			return errors.WithMessage(r, "failed writing cache")
+		}
+	}
+	return nil
+}
+
+// encodeCallGraph converts the call graph for restoreCallGraph. Functions of superseded packages are left out
+// as well as functions that can not be found again without the function bodies of the dependencies:
+// anonymous functions, instances and wrappers within dependencies.
+func (s *Module) encodeCallGraph() *cachedGraph {
+	known := functionsOf(s.program, s.SSAPkgs, func(fn *ssa.Function) bool {
+		return isSuperseded(fn, s.superseded)
+	})
+
+	nodes := make([]*callgraph.Node, 0, len(s.Graph.Nodes))
+	for fn, node := range s.Graph.Nodes {
+		if fn != nil && !isSuperseded(fn, s.superseded) {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	graph := &cachedGraph{Funcs: make([]cachedFunc, 0, len(nodes))}
+	indices := make(map[*callgraph.Node]int, len(nodes))
+	for _, node := range nodes {
+		fn := funcDecl(node.Func)
+		if fn.Decl == "" && !known[node.Func] {
+			continue
+		}
+		fn.Name = node.Func.String()
+		indices[node] = len(graph.Funcs)
+		graph.Funcs = append(graph.Funcs, fn)
+	}
+
+	for _, node := range nodes {
+		caller, ok := indices[node]
+		if !ok {
+			continue
+		}
+		sites := map[ssa.CallInstruction]int{}
+		for i, site := range callSites(node.Func) {
+			sites[site] = i
+		}
+		for _, edge := range node.Out {
+			callee, ok := indices[edge.Callee]
+			if !ok {
+				continue
+			}
+			site, ok := sites[edge.Site]
+			if !ok {
+				site = -1
+			}
+			graph.Edges = append(graph.Edges, cachedEdge{Caller: caller, Callee: callee, Site: site})
+		}
+	}
+	return graph
+}
+
+// restoreCallGraph creates the call graph encoded by encodeCallGraph for the current SSA program. Edges of
+// functions that do not exist in the program anymore are dropped. Calls made by dependencies loaded from
+// export data have no call site.
+func (s *Module) restoreCallGraph(cached *cachedGraph) *callgraph.Graph {
+	byName := map[string]*ssa.Function{}
+	for fn := range functionsOf(s.program, s.SSAPkgs, nil) {
+		byName[fn.String()] = fn
+	}
+
+	graph := callgraph.New(nil)
+	nodes := make([]*callgraph.Node, len(cached.Funcs))
+	for i, f := range cached.Funcs {
+		fn, ok := byName[f.Name]
+		if !ok {
+			fn = f.resolve(s.program)
+		}
+		if fn != nil {
+			nodes[i] = graph.CreateNode(fn)
+		}
+	}
+
+	sites := map[*ssa.Function][]ssa.CallInstruction{}
+	for _, edge := range cached.Edges {
+		caller, callee := nodes[edge.Caller], nodes[edge.Callee]
+		if caller == nil || callee == nil {
+			continue
+		}
+		var site ssa.CallInstruction
+		if edge.Site >= 0 {
+			calls, ok := sites[caller.Func]
+			if !ok {
+				calls = callSites(caller.Func)
+				sites[caller.Func] = calls
+			}
+			if edge.Site < len(calls) {
+				site = calls[edge.Site]
+			}
+		}
+		callgraph.AddEdge(caller, site, callee)
+	}
+	return graph
+}
+
+// funcDecl returns the declaration of a function declared in a package or of a method of a named type.
+// Other functions have no declaration.
+func funcDecl(fn *ssa.Function) cachedFunc {
+	if fn.Parent() != nil || len(fn.TypeArgs()) != 0 {
+		return cachedFunc{}
+	}
+
+	recv := fn.Signature.Recv()
+	if recv == nil {
+		if fn.Pkg == nil || fn.Pkg.Func(fn.Name()) != fn {
+			return cachedFunc{}
+		}
+		return cachedFunc{Pkg: fn.Pkg.Pkg.Path(), Decl: fn.Name()}
+	}
+
+	method, ok := fn.Object().(*types.Func)
+	if !ok || method.Pkg() == nil {
+		return cachedFunc{}
+	}
+	var ptr string
+	recvType := types.Unalias(recv.Type())
+	if p, ok := recvType.(*types.Pointer); ok {
+		ptr = "*"
+		recvType = types.Unalias(p.Elem())
+	}
+	named, ok := recvType.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.TypeArgs().Len() != 0 || named.TypeParams().Len() != 0 ||
+		types.IsInterface(named) {
+		return cachedFunc{}
+	}
+	return cachedFunc{
+		Pkg:  method.Pkg().Path(),
+		Recv: ptr + named.Obj().Pkg().Path() + "." + named.Obj().Name(),
+		Decl: method.Name(),
+	}
+}
+
+// resolve returns the declared function or method within the program.
+func (s cachedFunc) resolve(program *ssa.Program) *ssa.Function {
+	if s.Decl == "" {
+		return nil
+	}
+	pkg := program.ImportedPackage(s.Pkg)
+	if pkg == nil {
+		return nil
+	}
+	if s.Recv == "" {
+		return pkg.Func(s.Decl)
+	}
+
+	recv := strings.TrimPrefix(s.Recv, "*")
+	i := strings.LastIndex(recv, ".")
+	typePkg := program.ImportedPackage(recv[:i])
+	if typePkg == nil {
+		return nil
+	}
+	typ := typePkg.Type(recv[i+1:])
+	if typ == nil {
+		return nil
+	}
+	recvType := typ.Type()
+	if recv != s.Recv {
+		recvType = types.NewPointer(recvType)
+	}
+	sel := program.MethodSets.MethodSet(recvType).Lookup(pkg.Pkg, s.Decl)
+	if sel == nil {
+		return nil
+	}
+	return program.MethodValue(sel)
+}
+
+// callSites returns the call instructions of the function in order.
+func callSites(fn *ssa.Function) []ssa.CallInstruction {
+	var sites []ssa.CallInstruction
+	forEachCall(fn, func(site ssa.CallInstruction) {
+		sites = append(sites, site)
+	})
+	return sites
+}
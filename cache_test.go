@@ -0,0 +1,142 @@
+package astrav
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadCachedModule(t *testing.T, cacheDir string) *Module {
+	dir, err := filepath.Abs("testdata/module")
+	if err != nil {
+		t.Fatal(err)
+	}
+	module := NewModule(dir)
+	module.CacheDir = cacheDir
+	if r := module.Load(); r != nil {
+		t.Fatal(r)
+	}
+	return module
+}
+
+func TestModule_LoadCached(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	cold := loadCachedModule(t, cacheDir)
+	assert.False(t, cold.exportedDeps)
+	entries, err := os.ReadDir(cacheDir)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.FileExists(t, filepath.Join(cacheDir, entries[0].Name(), cacheIndexFile))
+		assert.FileExists(t, filepath.Join(cacheDir, entries[0].Name(), cacheExportFile))
+	}
+
+	warm := loadCachedModule(t, cacheDir)
+	assert.True(t, warm.exportedDeps)
+	assert.Len(t, warm.Pkgs, len(cold.Pkgs))
+	for pkgPath := range cold.Pkgs {
+		assert.NotNil(t, warm.Package(pkgPath), pkgPath)
+	}
+
+	shapes := warm.Package(modShapes)
+	call := warm.Package(modRender).FindFirstByNodeType(NodeTypeCallExpr).(*CallExpr)
+	assert.Equal(t, shapes.FuncDeclByName("Area"), call.Callee().Decl)
+
+	shout := warm.Package(modText).FuncDeclByName("Shout")
+	assert.Equal(t, "func(s string) string", shout.Signature().String())
+	assert.Equal(t, "strings", shout.FindFirstByNodeType(NodeTypeCallExpr).(*CallExpr).Callee().Func.Pkg().Path())
+
+	var edges int
+	for fn, node := range warm.Graph.Nodes {
+		if fn != nil && fn.Name() == "Describe" {
+			edges = len(node.Out)
+		}
+	}
+	assert.Equal(t, 1, edges)
+
+	// calls within unexported code of dependencies are not restored, all calls of the module are
+	coldEdges, warmEdges := graphEdges(cold), graphEdges(warm)
+	assert.Subset(t, coldEdges, warmEdges)
+	assert.Contains(t, warmEdges, "strings.ToUpper -> strings.Map")
+	assert.Equal(t, moduleEdges(coldEdges), moduleEdges(warmEdges))
+
+	changed, err := warm.UpdateFile("testdata/module/shapes/shapes.go", shapes.GetSource())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{modRender, modShapes}, changed)
+	assert.Equal(t, moduleEdges(coldEdges), moduleEdges(graphEdges(warm)))
+
+	// the call graph is transferred when the program is built from scratch
+	for i := 0; i < maxSuperseded; i++ {
+		_, err = warm.UpdateFile("testdata/module/shapes/shapes.go", shapes.GetSource())
+		assert.NoError(t, err)
+	}
+	assert.LessOrEqual(t, len(warm.superseded), maxSuperseded)
+	assert.Equal(t, warmEdges, graphEdges(warm))
+}
+
+// graphEdges returns the edges of the call graph of the module formatted as "caller -> callee" and sorted.
+func graphEdges(module *Module) []string {
+	var edges []string
+	for fn, node := range module.Graph.Nodes {
+		for _, edge := range node.Out {
+			edges = append(edges, fmt.Sprintf("%v -> %v", fn, edge.Callee.Func))
+		}
+	}
+	sort.Strings(edges)
+	return edges
+}
+
+// moduleEdges filters the edges formatted by graphEdges to the calls made by functions of the test module.
+func moduleEdges(edges []string) []string {
+	var filtered []string
+	for _, edge := range edges {
+		caller, _, _ := strings.Cut(edge, " -> ")
+		if strings.Contains(caller, "github.com/tehsphinx/astrav/testdata/module") {
+			filtered = append(filtered, edge)
+		}
+	}
+	return filtered
+}
+
+func TestModule_CacheKey(t *testing.T) {
+	root := t.TempDir()
+	writeFile := func(name, content string) {
+		name = filepath.Join(root, name)
+		if r := os.MkdirAll(filepath.Dir(name), 0o755); r != nil {
+			t.Fatal(r)
+		}
+		if r := os.WriteFile(name, []byte(content), 0o644); r != nil {
+			t.Fatal(r)
+		}
+	}
+	writeFile("app/go.mod", "module example.com/app\n\ngo 1.21\n\nrequire example.com/dep v0.0.0\n\nreplace example.com/dep => ../dep\n")
+	writeFile("dep/go.mod", "module example.com/dep\n\ngo 1.21\n")
+	writeFile("dep/dep.go", "package dep\n")
+
+	module := NewModule(filepath.Join(root, "app"))
+	cacheKey := func(files map[string][]byte) string {
+		key, err := module.cacheKey(files)
+		assert.NoError(t, err)
+		return key
+	}
+
+	files := map[string][]byte{"a.go": []byte("package a")}
+	key := cacheKey(files)
+	assert.Equal(t, key, cacheKey(map[string][]byte{"a.go": []byte("package a")}))
+	assert.NotEqual(t, key, cacheKey(map[string][]byte{"a.go": []byte("package b")}))
+	assert.NotEqual(t, key, cacheKey(map[string][]byte{"b.go": []byte("package a")}))
+
+	goFlags := os.Getenv("GOFLAGS")
+	t.Setenv("GOFLAGS", goFlags+" -tags=astrav")
+	assert.NotEqual(t, key, cacheKey(files))
+	t.Setenv("GOFLAGS", goFlags)
+	assert.Equal(t, key, cacheKey(files))
+
+	writeFile("dep/dep.go", "package dep\n\nconst X = 1\n")
+	assert.NotEqual(t, key, cacheKey(files))
+}
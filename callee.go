@@ -0,0 +1,144 @@
+package astrav
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// CallKind classifies call expressions
+type CallKind string
+
+// Kinds of calls
+const (
+	// CallUnknown is returned without type information.
+	CallUnknown CallKind = ""
+	// CallBuiltin is a call of a builtin function, e.g. len(s).
+	CallBuiltin CallKind = "builtin"
+	// CallConversion is a type conversion, e.g. int64(x).
+	CallConversion CallKind = "conversion"
+	// CallFunction is a call of a package level function, e.g. strings.Contains(s, x).
+	CallFunction CallKind = "function"
+	// CallMethod is a statically dispatched method call, e.g. obj.Method().
+	CallMethod CallKind = "method"
+	// CallInterface is a method call dispatched via an interface, e.g. err.Error().
+	CallInterface CallKind = "interface"
+	// CallDynamic is a call of a function value, e.g. a closure or func() {...}().
+	CallDynamic CallKind = "dynamic"
+)
+
+// Callee describes the function called by a call expression.
+type Callee struct {
+	// Kind classifies the call.
+	Kind CallKind
+	// Func is the called function or method. It is nil for builtins, conversions and dynamic calls.
+	Func *types.Func
+	// Builtin is the called builtin function.
+	Builtin *types.Builtin
+	// Type is the target type of a conversion.
+	Type types.Type
+	// Decl is the FuncDecl or FuncLit of the called function if it is loaded. For dynamic calls
+	// of variables it is the function literal the variable is initialized with.
+	Decl Node
+}
+
+// Callee resolves the function called by the call expression. The returned callee has
+// kind CallUnknown if no type information is available.
+func (s *CallExpr) Callee() *Callee {
+	if s.Pkg() == nil || s.Info() == nil {
+		return &Callee{}
+	}
+
+	info := s.Info()
+	fun := ast.Unparen(s.CallExpr.Fun)
+	tv := info.Types[fun]
+	if tv.IsType() {
+		return &Callee{Kind: CallConversion, Type: tv.Type}
+	}
+
+	// unwrap explicit instantiations of generic functions
+	switch t := fun.(type) {
+	case *ast.IndexExpr:
+		fun = ast.Unparen(t.X)
+	case *ast.IndexListExpr:
+		fun = ast.Unparen(t.X)
+	}
+
+	var obj types.Object
+	switch t := fun.(type) {
+	case *ast.Ident:
+		obj = info.Uses[t]
+	case *ast.SelectorExpr:
+		if sel := info.Selections[t]; sel != nil {
+			if sel.Kind() == types.MethodVal && types.IsInterface(sel.Recv()) {
+				fn, _ := sel.Obj().(*types.Func)
+				return &Callee{Kind: CallInterface, Func: fn}
+			}
+			obj = sel.Obj()
+		} else {
+			obj = info.Uses[t.Sel]
+		}
+	case *ast.FuncLit:
+		return &Callee{Kind: CallDynamic, Decl: s.findChildByAstNode(t)}
+	}
+
+	switch o := obj.(type) {
+	case *types.Builtin:
+		return &Callee{Kind: CallBuiltin, Builtin: o}
+	case *types.Func:
+		kind := CallFunction
+		if o.Signature().Recv() != nil {
+			kind = CallMethod
+		}
+		return &Callee{Kind: kind, Func: o, Decl: s.Pkg().DeclarationOf(o)}
+	case *types.Var:
+		return &Callee{Kind: CallDynamic, Decl: s.Pkg().funcLitOf(o)}
+	}
+	return &Callee{Kind: CallDynamic}
+}
+
+// funcLitOf returns the function literal a variable is initialized with.
+func (s *Package) funcLitOf(v *types.Var) Node {
+	decl := s.DeclarationOf(v)
+	if decl == nil {
+		return nil
+	}
+
+	var (
+		root   Node = decl
+		values []ast.Expr
+		index  = -1
+	)
+	switch d := decl.(type) {
+	case *ValueSpec:
+		values = d.ValueSpec.Values
+		for i, name := range d.ValueSpec.Names {
+			if name.Name == v.Name() {
+				index = i
+			}
+		}
+	case *Ident:
+		assign, ok := d.Parent().(*AssignStmt)
+		if !ok || assign.AssignStmt.Tok != token.DEFINE {
+			return nil
+		}
+		root = assign
+		values = assign.AssignStmt.Rhs
+		for i, lhs := range assign.AssignStmt.Lhs {
+			if lhs == d.Ident {
+				index = i
+			}
+		}
+	}
+	if index < 0 || len(values) <= index {
+		return nil
+	}
+
+	lit, ok := ast.Unparen(values[index]).(*ast.FuncLit)
+	if !ok {
+		return nil
+	}
+	return root.TreeNode(func(n Node) bool {
+		return n.AstNode() == lit
+	})
+}
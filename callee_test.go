@@ -0,0 +1,85 @@
+package astrav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallExpr_Callee(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/calls")
+
+	type result struct {
+		Kind CallKind
+		Name string
+		Decl NodeType
+	}
+	results := map[string]result{}
+	for _, node := range pkg.FindFirstByName("Run").FindByNodeType(NodeTypeCallExpr) {
+		call := node.(*CallExpr)
+		callee := call.Callee()
+
+		res := result{Kind: callee.Kind}
+		switch {
+		case callee.Func != nil:
+			res.Name = callee.Func.FullName()
+		case callee.Builtin != nil:
+			res.Name = callee.Builtin.Name()
+		case callee.Type != nil:
+			res.Name = callee.Type.String()
+		}
+		if callee.Decl != nil {
+			res.Decl = callee.Decl.NodeType()
+		}
+		key := call.GetSourceString()
+		if len(key) > 20 {
+			key = key[:20]
+		}
+		results[key] = res
+	}
+
+	assert.Equal(t, map[string]result{
+		"make([]Shape, 0, 2)":     {Kind: CallBuiltin, Name: "make"},
+		"append(shapes, Squar":    {Kind: CallBuiltin, Name: "append"},
+		"int64(len(shapes))":      {Kind: CallConversion, Name: "int64"},
+		"len(shapes)":             {Kind: CallBuiltin, Name: "len"},
+		"sq.Area()":               {Kind: CallMethod, Name: "(Square).Area", Decl: NodeTypeFuncDecl},
+		"double(area)":            {Kind: CallDynamic, Decl: NodeTypeFuncLit},
+		"(Square).Area(sq)":       {Kind: CallMethod, Name: "(Square).Area", Decl: NodeTypeFuncDecl},
+		"total(shapes)":           {Kind: CallFunction, Name: "total", Decl: NodeTypeFuncDecl},
+		"func() {\n\t\tfmt.Print": {Kind: CallDynamic, Decl: NodeTypeFuncLit},
+		"fmt.Println(strings.":    {Kind: CallFunction, Name: "fmt.Println"},
+		"strings.Repeat(\"x\", ":  {Kind: CallFunction, Name: "strings.Repeat"},
+		"int(n)":                  {Kind: CallConversion, Name: "int"},
+	}, results)
+
+	shapeCall := pkg.FindFirstByName("total").FindFirstByNodeType(NodeTypeCallExpr).(*CallExpr)
+	callee := shapeCall.Callee()
+	assert.Equal(t, CallInterface, callee.Kind)
+	assert.Equal(t, "Area", callee.Func.Name())
+	assert.Nil(t, callee.Decl)
+}
+
+func TestPackage_FuncDeclbyCallExpr(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/calls").(*Package)
+	run := pkg.FuncDeclByName("Run")
+
+	callDecls := func() []string {
+		var decls []string
+		for _, call := range run.FindByNodeType(NodeTypeCallExpr) {
+			if decl := pkg.FuncDeclbyCallExpr(call.(*CallExpr)); decl != nil {
+				decls = append(decls, decl.NodeName())
+			}
+		}
+		return decls
+	}
+
+	// by default functions are looked up by name and methods are not followed
+	assert.Equal(t, []string{"total"}, callDecls())
+	assert.Equal(t, 1, len(run.FindNodeTypeInCallTree(NodeTypeBinaryExpr)))
+	assert.Equal(t, 3, len(run.FindNameInCallTree("sum")))
+
+	pkg.SetResolveCallees(true)
+	assert.Equal(t, []string{"Area", "Area", "total"}, callDecls())
+	assert.Equal(t, 2, len(run.FindNodeTypeInCallTree(NodeTypeBinaryExpr)))
+}
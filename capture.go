@@ -0,0 +1,158 @@
+package astrav
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// Capture is a variable of an outer scope referenced within a function literal.
+type Capture struct {
+	// Decl is the declaring identifier of the variable.
+	Decl *Ident
+	// Var is the captured variable.
+	Var *types.Var
+	// Usages are the references to the variable within the function literal.
+	Usages []*Ident
+	// Written is true if the variable is assigned, incremented, has its address taken or
+	// a method with pointer receiver is called on it within the function literal.
+	Written bool
+}
+
+// Captures returns the local variables of outer scopes referenced within the function literal,
+// ordered by their first usage. Package level variables are not captured.
+func (s *FuncLit) Captures() []*Capture {
+	info := s.Info()
+	if info == nil {
+		return nil
+	}
+	litScope := info.Scopes[s.FuncLit.Type]
+
+	var (
+		captures []*Capture
+		byVar    = map[*types.Var]*Capture{}
+		writes   = writtenObjects(s)
+	)
+	for _, node := range s.FindByNodeType(NodeTypeIdent) {
+		ident := node.(*Ident)
+		v, ok := info.Uses[ident.Ident].(*types.Var)
+		if !ok || v.IsField() || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() || innerScope(v.Parent(), litScope) {
+			continue
+		}
+
+		capture, ok := byVar[v]
+		if !ok {
+			capture = &Capture{
+				Decl:    s.Pkg().FindDeclaration(ident),
+				Var:     v,
+				Written: writes[v],
+			}
+			byVar[v] = capture
+			captures = append(captures, capture)
+		}
+		capture.Usages = append(capture.Usages, ident)
+	}
+	return captures
+}
+
+// innerScope checks if the scope is the outer scope or nested within it.
+func innerScope(scope, outer *types.Scope) bool {
+	for ; scope != nil; scope = scope.Parent() {
+		if scope == outer {
+			return true
+		}
+	}
+	return false
+}
+
+// Escapes checks if the function literal may outlive the function it is declared in or runs
+// after the surrounding code: it is returned, stored in a field, element or package level variable,
+// sent on a channel, passed to a function or started with go or defer. A function literal assigned
+// to a local variable escapes if the variable does.
+func (s *FuncLit) Escapes() bool {
+	return valueEscapes(s, map[types.Object]bool{})
+}
+
+// valueEscapes checks if the function value of the node escapes.
+func valueEscapes(node Node, visited map[types.Object]bool) bool {
+	expr, parent := node, node.Parent()
+	for {
+		paren, ok := parent.(*ParenExpr)
+		if !ok {
+			break
+		}
+		expr, parent = paren, paren.Parent()
+	}
+
+	switch p := parent.(type) {
+	case *CallExpr:
+		if p.CallExpr.Fun != expr.AstNode() {
+			return true
+		}
+		return p.Parent().IsNodeType(NodeTypeGoStmt) || p.Parent().IsNodeType(NodeTypeDeferStmt)
+	case *ReturnStmt, *SendStmt, *CompositeLit, *KeyValueExpr:
+		return true
+	case *AssignStmt:
+		for i, rhs := range p.AssignStmt.Rhs {
+			if rhs == expr.AstNode() && len(p.AssignStmt.Lhs) == len(p.AssignStmt.Rhs) {
+				return varEscapes(p, p.AssignStmt.Lhs[i], visited)
+			}
+		}
+		return true
+	case *ValueSpec:
+		for i, value := range p.ValueSpec.Values {
+			if value == expr.AstNode() && len(p.ValueSpec.Names) == len(p.ValueSpec.Values) {
+				return varEscapes(p, p.ValueSpec.Names[i], visited)
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// varEscapes checks if a function value assigned to the expression escapes.
+func varEscapes(node Node, lhs ast.Expr, visited map[types.Object]bool) bool {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		return true
+	}
+	if ident.Name == "_" {
+		return false
+	}
+
+	v, ok := node.Info().ObjectOf(ident).(*types.Var)
+	if !ok || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+		return true
+	}
+	if visited[v] {
+		return false
+	}
+	visited[v] = true
+
+	pkg := node.Pkg()
+	for _, usage := range pkg.FindByNodeType(NodeTypeIdent) {
+		if usage.Pos() == ident.Pos() || node.Info().Uses[usage.(*Ident).Ident] != v {
+			continue
+		}
+		if isAssignTarget(usage) {
+			continue
+		}
+		if valueEscapes(usage, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAssignTarget checks if the identifier is assigned to.
+func isAssignTarget(ident Node) bool {
+	assign, ok := ident.Parent().(*AssignStmt)
+	if !ok {
+		return false
+	}
+	for _, lhs := range assign.AssignStmt.Lhs {
+		if lhs == ident.AstNode() {
+			return true
+		}
+	}
+	return false
+}
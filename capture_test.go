@@ -0,0 +1,40 @@
+package astrav
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncLit_Captures(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/closures")
+
+	var (
+		captures []string
+		escapes  []bool
+	)
+	for _, node := range pkg.FindByNodeType(NodeTypeFuncLit) {
+		lit := node.(*FuncLit)
+		escapes = append(escapes, lit.Escapes())
+
+		var res string
+		for _, capture := range lit.Captures() {
+			res += fmt.Sprintf("%s:%d:%t ", capture.Var.Name(), len(capture.Usages), capture.Written)
+			assert.Equal(t, capture.Var.Pos(), capture.Decl.Pos())
+		}
+		captures = append(captures, res)
+	}
+
+	assert.Equal(t, []string{
+		"count:2:true ",
+		"total:1:true ",
+		"values:2:false limit:1:false ",
+		"total:1:true ",
+		"",
+		"limit:1:false ",
+		"ptr:1:true total:1:true limit:1:false ",
+		"limit:1:false ",
+	}, captures)
+	assert.Equal(t, []bool{true, false, true, false, true, true, true, false}, escapes)
+}
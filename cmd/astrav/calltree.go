@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tehsphinx/astrav"
+)
+
+type call struct {
+	Name      string  `json:"name"`
+	Pos       string  `json:"pos,omitempty"`
+	Recursive bool    `json:"recursive,omitempty"`
+	Calls     []*call `json:"calls,omitempty"`
+}
+
+func runCallTree(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("calltree", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the call tree as JSON")
+	funcName := flags.String("func", "", "name of the function to start from")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *funcName == "" {
+		return errors.New("calltree: -func is required")
+	}
+
+	nodes, err := load(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	var trees []*call
+	for _, node := range nodes {
+		fn := findFunc(node, *funcName)
+		if fn == nil {
+			continue
+		}
+		trees = append(trees, callTree(fn, fn.NodeName(), map[*astrav.FuncDecl]bool{}))
+	}
+	if len(trees) == 0 {
+		return fmt.Errorf("calltree: function %q not found", *funcName)
+	}
+
+	if *asJSON {
+		return writeJSON(stdout, trees)
+	}
+	for _, tree := range trees {
+		if r := printCallTree(stdout, tree, 0); r != nil {
+			return r
+		}
+	}
+	return nil
+}
+
+func findFunc(node astrav.Node, name string) *astrav.FuncDecl {
+	if pkg, ok := node.(*astrav.Package); ok {
+		return pkg.FuncDeclByName(name)
+	}
+
+	fn := node.TreeNode(func(n astrav.Node) bool {
+		f, ok := n.(*astrav.FuncDecl)
+		return ok && f.NodeName() == name
+	})
+	if fn == nil {
+		return nil
+	}
+	return fn.(*astrav.FuncDecl)
+}
+
+func callTree(fn *astrav.FuncDecl, name string, path map[*astrav.FuncDecl]bool) *call {
+	c := &call{
+		Name: name,
+		Pos:  fn.Position().String(),
+	}
+	if path[fn] {
+		c.Recursive = true
+		return c
+	}
+	path[fn] = true
+	defer delete(path, fn)
+
+	for _, node := range fn.FindByNodeType(astrav.NodeTypeCallExpr) {
+		callExpr := node.(*astrav.CallExpr)
+		callName := callExpr.SelExpr().GetSourceString()
+
+		var callee *astrav.FuncDecl
+		if fn.Pkg() != nil {
+			callee = fn.Pkg().FuncDeclbyCallExpr(callExpr)
+		}
+		if callee == nil {
+			c.Calls = append(c.Calls, &call{
+				Name: callName,
+				Pos:  callExpr.Position().String(),
+			})
+			continue
+		}
+
+		c.Calls = append(c.Calls, callTree(callee, callName, path))
+	}
+	return c
+}
+
+func printCallTree(w io.Writer, c *call, level int) error {
+	suffix := ""
+	if c.Recursive {
+		suffix = " (recursive)"
+	}
+	if _, err := fmt.Fprintf(w, "%s%s %s%s\n", strings.Repeat("\t", level), c.Name, c.Pos, suffix); err != nil {
+		return err
+	}
+
+	for _, sub := range c.Calls {
+		if err := printCallTree(w, sub, level+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
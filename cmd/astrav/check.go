@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+func runCheck(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the diagnostics as JSON")
+	ruleFile := flags.String("rules", "", "rule file in YAML or JSON format")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *ruleFile == "" {
+		return errors.New("check: -rules is required")
+	}
+
+	set, err := rules.Load(*ruleFile)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := load(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	var diags []*rules.Diagnostic
+	for _, node := range nodes {
+		pkg := node.Pkg()
+		if pkg == nil {
+			return fmt.Errorf("check: %s is not part of a package", node.Position().Filename)
+		}
+
+		pkgDiags := set.Check(pkg)
+		if !node.IsNodeType(astrav.NodeTypeFile) {
+			diags = append(diags, pkgDiags...)
+			continue
+		}
+		// rules are checked on the package, only keep the diagnostics of the requested file
+		// and those without a position such as missing must-exist matches
+		fileName := node.Position().Filename
+		for _, diag := range pkgDiags {
+			if diag.Pos.Filename == "" || diag.Pos.Filename == fileName {
+				diags = append(diags, diag)
+			}
+		}
+	}
+
+	if *asJSON {
+		if r := writeJSON(stdout, diags); r != nil {
+			return r
+		}
+	} else {
+		for _, diag := range diags {
+			if _, r := fmt.Fprintln(stdout, diag); r != nil {
+				return r
+			}
+		}
+	}
+
+	var errCount int
+	for _, diag := range diags {
+		if diag.Severity == rules.SeverityError {
+			errCount++
+		}
+	}
+	if errCount != 0 {
+		return fmt.Errorf("check: %d error(s) found", errCount)
+	}
+	return nil
+}
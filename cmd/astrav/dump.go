@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"io"
+
+	"github.com/tehsphinx/astrav/dump"
+)
+
+func runDump(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("dump", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the tree as JSON")
+	asDOT := flags.Bool("dot", false, "print the tree in Graphviz DOT format")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	nodes, err := load(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	printFn := dump.Text
+	switch {
+	case *asJSON:
+		printFn = dump.JSON
+	case *asDOT:
+		printFn = dump.DOT
+	}
+
+	for _, node := range nodes {
+		if r := printFn(stdout, node); r != nil {
+			return r
+		}
+	}
+	return nil
+}
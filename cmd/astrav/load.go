@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/tehsphinx/astrav"
+)
+
+// load loads the given paths and returns the root nodes to work on. Files result in File nodes,
+// directories in Package nodes. Directories containing a go.mod file are loaded as Module with
+// all its packages.
+func load(paths []string) ([]astrav.Node, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var nodes []astrav.Node
+	for _, p := range paths {
+		loaded, err := loadPath(p)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, loaded...)
+	}
+	return nodes, nil
+}
+
+func loadPath(p string) ([]astrav.Node, error) {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	if !stat.IsDir() {
+		return loadFile(abs)
+	}
+	if _, err := os.Stat(filepath.Join(abs, "go.mod")); err == nil {
+		return loadModule(abs)
+	}
+	return loadFolder(abs)
+}
+
+func loadFile(file string) ([]astrav.Node, error) {
+	pkgs, err := loadFolder(filepath.Dir(file))
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(file)
+	for _, pkg := range pkgs {
+		for _, f := range pkg.FindByNodeType(astrav.NodeTypeFile) {
+			if filepath.Base(f.Position().Filename) == name {
+				return []astrav.Node{f}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("file %s not found in its package", file)
+}
+
+func loadFolder(dir string) ([]astrav.Node, error) {
+	folder := astrav.NewFolder(http.Dir(dir), "")
+	pkgs, err := folder.ParseFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	nodes := make([]astrav.Node, 0, len(names))
+	for _, name := range names {
+		nodes = append(nodes, pkgs[name])
+	}
+	return nodes, nil
+}
+
+func loadModule(dir string) ([]astrav.Node, error) {
+	module := astrav.NewModule(dir)
+	if err := module.Load(); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(module.Pkgs))
+	for pkgPath := range module.Pkgs {
+		paths = append(paths, pkgPath)
+	}
+	sort.Strings(paths)
+
+	nodes := make([]astrav.Node, 0, len(paths))
+	for _, pkgPath := range paths {
+		nodes = append(nodes, module.Pkgs[pkgPath])
+	}
+	return nodes, nil
+}
@@ -0,0 +1,84 @@
+// Command astrav queries and dumps Go code using the astrav library.
+//
+// Usage:
+//
+//	astrav <command> [flags] <path>...
+//
+// The path can be a Go file, a package directory or the root of a module (a directory
+// containing a go.mod file). The commands are:
+//
+//	dump      print the tree of a file, package or module
+//	query     print all nodes matching the given query with their positions
+//	calltree  print the call tree of a function
+//	metrics   print code metrics per package and function
+//	check     check the code against the rules of a YAML or JSON rule file
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{name: "dump", usage: "print the tree of a file, package or module", run: runDump},
+	{name: "query", usage: "print all nodes matching the given query with their positions", run: runQuery},
+	{name: "calltree", usage: "print the call tree of a function", run: runCallTree},
+	{name: "metrics", usage: "print code metrics per package and function", run: runMetrics},
+	{name: "check", usage: "check the code against the rules of a YAML or JSON rule file", run: runCheck},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "astrav:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		usage(stdout)
+		return fmt.Errorf("no command given")
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout)
+		}
+	}
+
+	usage(stdout)
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: astrav <command> [flags] <path>...")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	names := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		names = append(names, cmd.name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, cmd := range commands {
+			if cmd.name == name {
+				fmt.Fprintf(w, "\t%-10s %s\n", cmd.name, cmd.usage)
+			}
+		}
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun_UnknownCommand(t *testing.T) {
+	var buf bytes.Buffer
+	err := run([]string{"unknown"}, &buf)
+	assert.Error(t, err)
+	assert.Contains(t, buf.String(), "usage: astrav")
+}
+
+func TestRun_Dump(t *testing.T) {
+	var buf bytes.Buffer
+	err := run([]string{"dump", "../../example/6/example.go"}, &buf)
+	assert.NoError(t, err)
+
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, "File name=twofer pos=example.go:1:1", lines[0])
+	assert.Equal(t, "\tIdent name=twofer pos=example.go:1:9", lines[1])
+}
+
+func TestRun_Query(t *testing.T) {
+	var buf bytes.Buffer
+	err := run([]string{"query", "-name", "SumOfSquares", "-node", "CallExpr", "../../example/5"}, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.go:20:40\tCallExpr\tSumOfSquares(i-1)\n"+
+		"example.go:25:26\tCallExpr\tSumOfSquares(i)\n", buf.String())
+
+	buf.Reset()
+	err = run([]string{"query", "-json", "-vtype", "map[string]int", "../../example/8"}, &buf)
+	assert.NoError(t, err)
+
+	var matches []*match
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &matches))
+	assert.Equal(t, 4, len(matches))
+	assert.Equal(t, "Ident", matches[0].Type)
+	assert.Equal(t, "scoreMap", matches[0].Name)
+
+	err = run([]string{"query", "../../example/8"}, &buf)
+	assert.Error(t, err)
+}
+
+func TestRun_CallTree(t *testing.T) {
+	var buf bytes.Buffer
+	err := run([]string{"calltree", "-json", "-func", "Difference", "../../example/5"}, &buf)
+	assert.NoError(t, err)
+
+	var trees []*call
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &trees))
+	assert.Equal(t, 1, len(trees))
+	assert.Equal(t, "Difference", trees[0].Name)
+	assert.Equal(t, 2, len(trees[0].Calls))
+
+	sumOfSquares := trees[0].Calls[1]
+	assert.Equal(t, "SumOfSquares", sumOfSquares.Name)
+	assert.True(t, sumOfSquares.Calls[len(sumOfSquares.Calls)-1].Recursive)
+}
+
+func TestRun_Metrics(t *testing.T) {
+	var buf bytes.Buffer
+	err := run([]string{"metrics", "-json", "../../example/1"}, &buf)
+	assert.NoError(t, err)
+
+	var metrics []*pkgMetrics
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &metrics))
+	assert.Equal(t, 1, len(metrics))
+	assert.Equal(t, "scrabble", metrics[0].Name)
+	assert.Equal(t, 2, len(metrics[0].Funcs))
+	assert.Equal(t, "valor", metrics[0].Funcs[1].Name)
+	assert.Equal(t, 8, metrics[0].Funcs[1].Complexity)
+	assert.Equal(t, 35, metrics[0].Lines)
+	assert.Equal(t, 20, metrics[0].Funcs[1].Lines)
+}
+
+func TestRun_MetricsWriteError(t *testing.T) {
+	err := run([]string{"metrics", "../../example/1"}, failingWriter{})
+	assert.Error(t, err)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestCountLines(t *testing.T) {
+	assert.Equal(t, 0, countLines(nil))
+	assert.Equal(t, 1, countLines([]byte("a")))
+	assert.Equal(t, 1, countLines([]byte("a\n")))
+	assert.Equal(t, 2, countLines([]byte("a\nb")))
+}
+
+func TestRun_Check(t *testing.T) {
+	var buf bytes.Buffer
+	err := run([]string{"check", "-rules", "../../rules/testdata/hamming.yaml", "../../example/7"}, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.go:45:4: info: counting inside a loop (no-increment-in-loop)\n"+
+		"example.go:55:2: warning: do not print to stdout (no-println)\n", buf.String())
+
+	buf.Reset()
+	err = run([]string{"check", "-rules", "../../rules/testdata/hamming.yaml", "../../example/5"}, &buf)
+	assert.EqualError(t, err, "check: 1 error(s) found")
+	assert.Equal(t, "error: Distance should compare the length of both strings (distance-checks-length)\n", buf.String())
+}
+
+func TestRun_CheckFile(t *testing.T) {
+	var buf bytes.Buffer
+	err := run([]string{"check", "-rules", "../../rules/testdata/hamming.yaml", "testdata/printer/second.go"}, &buf)
+	assert.EqualError(t, err, "check: 1 error(s) found")
+	assert.Equal(t, "error: Distance should compare the length of both strings (distance-checks-length)\n"+
+		"second.go:7:2: warning: do not print to stdout (no-println)\n", buf.String())
+
+	buf.Reset()
+	err = run([]string{"check", "-rules", "../../rules/testdata/hamming.yaml", "../../example/5/example.go"}, &buf)
+	assert.EqualError(t, err, "check: 1 error(s) found")
+	assert.Equal(t, "error: Distance should compare the length of both strings (distance-checks-length)\n", buf.String())
+}
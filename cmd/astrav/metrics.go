@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
+	"text/tabwriter"
+
+	"github.com/tehsphinx/astrav"
+)
+
+type pkgMetrics struct {
+	Name  string         `json:"name"`
+	Files int            `json:"files"`
+	Lines int            `json:"lines"`
+	Types int            `json:"types"`
+	Nodes int            `json:"nodes"`
+	Funcs []*funcMetrics `json:"funcs"`
+}
+
+type funcMetrics struct {
+	Name       string `json:"name"`
+	Pos        string `json:"pos"`
+	Lines      int    `json:"lines"`
+	Statements int    `json:"statements"`
+	Complexity int    `json:"complexity"`
+}
+
+func runMetrics(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("metrics", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the metrics as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	nodes, err := load(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	metrics := make([]*pkgMetrics, 0, len(nodes))
+	for _, node := range nodes {
+		metrics = append(metrics, collectMetrics(node))
+	}
+
+	if *asJSON {
+		return writeJSON(stdout, metrics)
+	}
+
+	for _, m := range metrics {
+		if _, err := fmt.Fprintf(stdout, "%s files: %d lines: %d types: %d nodes: %d\n",
+			m.Name, m.Files, m.Lines, m.Types, m.Nodes); err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+		for _, f := range m.Funcs {
+			if _, err := fmt.Fprintf(w, "\t%s\tlines: %d\tstatements: %d\tcomplexity: %d\t%s\n",
+				f.Name, f.Lines, f.Statements, f.Complexity, f.Pos); err != nil {
+				return err
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func collectMetrics(root astrav.Node) *pkgMetrics {
+	m := &pkgMetrics{}
+	if named, ok := root.(astrav.Named); ok {
+		m.Name = named.NodeName()
+	}
+
+	files := root.FindByNodeType(astrav.NodeTypeFile)
+	if root.IsNodeType(astrav.NodeTypeFile) {
+		files = append(files, root)
+	}
+	m.Files = len(files)
+	for _, file := range files {
+		m.Lines += countLines(file.GetSource())
+	}
+
+	m.Types = len(root.FindByNodeType(astrav.NodeTypeTypeSpec))
+	root.Walk(func(node astrav.Node) bool {
+		m.Nodes++
+		return true
+	})
+
+	for _, node := range root.FindByNodeType(astrav.NodeTypeFuncDecl) {
+		m.Funcs = append(m.Funcs, &funcMetrics{
+			Name:       funcName(node.(*astrav.FuncDecl)),
+			Pos:        node.Position().String(),
+			Lines:      countLines(node.GetSource()),
+			Statements: countStatements(node),
+			Complexity: complexity(node),
+		})
+	}
+	return m
+}
+
+// countLines returns the number of lines of the source. A final line without line break is counted as well.
+func countLines(src []byte) int {
+	lines := bytes.Count(src, []byte{'\n'})
+	if len(src) != 0 && src[len(src)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
+
+// funcName returns the name of a function prefixed with its receiver type for methods.
+func funcName(fn *astrav.FuncDecl) string {
+	name := fn.NodeName()
+	if fn.Pkg() == nil || fn.Info() == nil {
+		return name
+	}
+
+	obj, ok := fn.GetIdent().Object().(*types.Func)
+	if !ok {
+		return name
+	}
+	sig := obj.Type().(*types.Signature)
+	if sig.Recv() == nil {
+		return name
+	}
+
+	recv := types.TypeString(sig.Recv().Type(), types.RelativeTo(obj.Pkg()))
+	return "(" + recv + ")." + name
+}
+
+func countStatements(fn astrav.Node) int {
+	var count int
+	fn.Walk(func(node astrav.Node) bool {
+		switch node.(type) {
+		case *astrav.BlockStmt, *astrav.EmptyStmt:
+		case *astrav.AssignStmt, *astrav.ExprStmt, *astrav.IncDecStmt, *astrav.ReturnStmt,
+			*astrav.BranchStmt, *astrav.IfStmt, *astrav.ForStmt, *astrav.RangeStmt,
+			*astrav.SwitchStmt, *astrav.TypeSwitchStmt, *astrav.SelectStmt, *astrav.DeclStmt,
+			*astrav.GoStmt, *astrav.DeferStmt, *astrav.SendStmt, *astrav.LabeledStmt:
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// complexity calculates the cyclomatic complexity of a function.
+func complexity(fn astrav.Node) int {
+	count := 1
+	fn.Walk(func(node astrav.Node) bool {
+		switch n := node.(type) {
+		case *astrav.IfStmt, *astrav.ForStmt, *astrav.RangeStmt:
+			count++
+		case *astrav.CaseClause:
+			if len(n.CaseClause.List) != 0 {
+				count++
+			}
+		case *astrav.CommClause:
+			if n.CommClause.Comm != nil {
+				count++
+			}
+		case *astrav.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				count++
+			}
+		}
+		return true
+	})
+	return count
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/dump"
+)
+
+type match struct {
+	*dump.Info
+	Source string `json:"source"`
+}
+
+func runQuery(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("query", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the matches as JSON")
+	name := flags.String("name", "", "match nodes by name (e.g. strings.ToLower)")
+	nodeType := flags.String("node", "", "match nodes by node type (e.g. CallExpr)")
+	valueType := flags.String("vtype", "", "match nodes by value type (e.g. map[string]int)")
+	pattern := flags.String("match", "", "match nodes whose source matches the regular expression")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cond, err := buildQuery(*name, *nodeType, *valueType, *pattern)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := load(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	var matches []*match
+	for _, node := range nodes {
+		for _, n := range node.TreeNodes(cond) {
+			matches = append(matches, &match{
+				Info:   dump.NodeInfo(n),
+				Source: snippet(n),
+			})
+		}
+	}
+
+	if *asJSON {
+		return writeJSON(stdout, matches)
+	}
+	for _, m := range matches {
+		if _, r := fmt.Fprintf(stdout, "%s\t%s\t%s\n", m.Pos, m.Type, m.Source); r != nil {
+			return r
+		}
+	}
+	return nil
+}
+
+func buildQuery(name, nodeType, valueType, pattern string) (func(n astrav.Node) bool, error) {
+	var conds []func(n astrav.Node) bool
+
+	if name != "" {
+		conds = append(conds, func(n astrav.Node) bool {
+			named, ok := n.(astrav.Named)
+			return ok && named.NodeName() == name
+		})
+	}
+	if nodeType != "" {
+		nt := astrav.NodeType("*astrav." + strings.TrimPrefix(nodeType, "*astrav."))
+		conds = append(conds, func(n astrav.Node) bool {
+			return n.IsNodeType(nt)
+		})
+	}
+	if valueType != "" {
+		conds = append(conds, func(n astrav.Node) bool {
+			return n.Pkg() != nil && n.Info() != nil && n.IsValueType(valueType)
+		})
+	}
+	if pattern != "" {
+		rx, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, func(n astrav.Node) bool {
+			return hasSource(n) && rx.Match(n.GetSource())
+		})
+	}
+
+	if len(conds) == 0 {
+		return nil, errors.New("query: at least one of -name, -node, -vtype or -match is required")
+	}
+
+	return func(n astrav.Node) bool {
+		for _, cond := range conds {
+			if !cond(n) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// snippet returns the first line of the source of a node.
+func snippet(node astrav.Node) string {
+	if !hasSource(node) {
+		return ""
+	}
+
+	src := node.GetSourceString()
+	if i := strings.IndexByte(src, '\n'); i != -1 {
+		src = src[:i] + " ..."
+	}
+	return src
+}
+
+// hasSource checks if the node was loaded with its raw source.
+func hasSource(node astrav.Node) bool {
+	pos := node.Position()
+	return pos.IsValid()
+}
@@ -0,0 +1,8 @@
+package printer
+
+import "fmt"
+
+// First prints the first greeting.
+func First() {
+	fmt.Println("first")
+}
@@ -0,0 +1,8 @@
+package printer
+
+import "fmt"
+
+// Second prints the second greeting.
+func Second() {
+	fmt.Println("second")
+}
@@ -0,0 +1,120 @@
+package concurrency
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/internal/detect"
+)
+
+// Channel collects the operations on a channel variable or field.
+type Channel struct {
+	// Obj is the variable or field holding the channel.
+	Obj types.Object
+	// Makes are the make calls assigned to the channel.
+	Makes []*astrav.CallExpr
+	// Sends are the send statements.
+	Sends []*astrav.SendStmt
+	// Receives are the receive operations (UnaryExpr) and range statements over the channel.
+	Receives []astrav.Node
+	// Closes are the calls of close.
+	Closes []*astrav.CallExpr
+}
+
+// Channels returns the channel operations grouped by channel object. The channels are sorted
+// by the position of their declaration.
+func Channels(root astrav.Node) []*Channel {
+	var (
+		channels = map[types.Object]*Channel{}
+		channel  = func(expr astrav.Node) *Channel {
+			obj := chanObject(expr)
+			if obj == nil {
+				return nil
+			}
+			if _, ok := channels[obj]; !ok {
+				channels[obj] = &Channel{Obj: obj}
+			}
+			return channels[obj]
+		}
+	)
+
+	root.Walk(func(node astrav.Node) bool {
+		switch n := node.(type) {
+		case *astrav.SendStmt:
+			if ch := channel(n.Chan()); ch != nil {
+				ch.Sends = append(ch.Sends, n)
+			}
+		case *astrav.UnaryExpr:
+			if n.UnaryExpr.Op != token.ARROW {
+				return true
+			}
+			if ch := channel(n.X()); ch != nil {
+				ch.Receives = append(ch.Receives, n)
+			}
+		case *astrav.RangeStmt:
+			if !n.X().HasValueType(astrav.IsChan) {
+				return true
+			}
+			if ch := channel(n.X()); ch != nil {
+				ch.Receives = append(ch.Receives, n)
+			}
+		case *astrav.CallExpr:
+			switch {
+			case detect.IsBuiltin(n, "close") && len(n.Args()) == 1:
+				if ch := channel(n.Args()[0]); ch != nil {
+					ch.Closes = append(ch.Closes, n)
+				}
+			case detect.IsBuiltin(n, "make") && n.HasValueType(astrav.IsChan):
+				if ch := channel(assignedTo(n)); ch != nil {
+					ch.Makes = append(ch.Makes, n)
+				}
+			}
+		}
+		return true
+	})
+
+	res := make([]*Channel, 0, len(channels))
+	for _, ch := range channels {
+		res = append(res, ch)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Obj.Pos() < res[j].Obj.Pos()
+	})
+	return res
+}
+
+// chanObject returns the variable or field an expression refers to.
+func chanObject(expr astrav.Node) types.Object {
+	if expr == nil || expr.Info() == nil {
+		return nil
+	}
+	switch e := ast.Unparen(expr.AstNode().(ast.Expr)).(type) {
+	case *ast.Ident:
+		return expr.Info().ObjectOf(e)
+	case *ast.SelectorExpr:
+		return expr.Info().ObjectOf(e.Sel)
+	}
+	return nil
+}
+
+// assignedTo returns the expression the value is assigned to in an assignment or variable declaration.
+func assignedTo(value astrav.Node) astrav.Node {
+	switch parent := value.Parent().(type) {
+	case *astrav.AssignStmt:
+		for i, rhs := range parent.RHS() {
+			if rhs == value && len(parent.LHS()) == len(parent.RHS()) {
+				return parent.LHS()[i]
+			}
+		}
+	case *astrav.ValueSpec:
+		for i, v := range parent.Values() {
+			if v == value && len(parent.Names()) == len(parent.Values()) {
+				return parent.Names()[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Package concurrency inspects goroutines, channels, mutexes and WaitGroups. The analyses return
+// astrav nodes; Detect reports the findings as diagnostics.
+//
+//	for _, g := range concurrency.Goroutines(pkg) {
+//		fmt.Println(g.Stmt.Position(), len(g.LoopVarCaptures))
+//	}
+package concurrency
+
+import (
+	"go/types"
+	"go/version"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/internal/detect"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+// Goroutine is a goroutine launch site.
+type Goroutine struct {
+	// Stmt is the go statement.
+	Stmt *astrav.GoStmt
+	// Func is the started FuncLit or, if loaded, the FuncDecl of the started function.
+	Func astrav.Node
+	// Captures are the variables declared outside of a started function literal.
+	Captures []*astrav.Capture
+	// LoopVarCaptures are the captures referring to variables of an enclosing loop. Before Go 1.22
+	// all goroutines started in the loop share these variables.
+	LoopVarCaptures []*astrav.Capture
+}
+
+// Goroutines returns the goroutine launch sites.
+func Goroutines(root astrav.Node) []*Goroutine {
+	var goroutines []*Goroutine
+	for _, node := range root.FindByNodeType(astrav.NodeTypeGoStmt) {
+		stmt := node.(*astrav.GoStmt)
+		g := &Goroutine{Stmt: stmt}
+		goroutines = append(goroutines, g)
+
+		callee := stmt.Call().Callee()
+		g.Func = callee.Decl
+		lit, ok := callee.Decl.(*astrav.FuncLit)
+		if !ok {
+			continue
+		}
+
+		g.Captures = lit.Captures()
+		loopVars := loopVariables(stmt)
+		for _, capture := range g.Captures {
+			if loopVars[capture.Var] {
+				g.LoopVarCaptures = append(g.LoopVarCaptures, capture)
+			}
+		}
+	}
+	return goroutines
+}
+
+// loopVariables returns the variables declared by the loops enclosing the node.
+func loopVariables(node astrav.Node) map[types.Object]bool {
+	vars := map[types.Object]bool{}
+	for loop := node.EnclosingLoop(); loop != nil; loop = loop.Parent() {
+		var idents []astrav.Node
+		switch n := loop.Node().(type) {
+		case *astrav.ForStmt:
+			if init := n.Init(); init != nil {
+				idents = init.FindByNodeType(astrav.NodeTypeIdent)
+			}
+		case *astrav.RangeStmt:
+			idents = []astrav.Node{n.Key(), n.Value()}
+		}
+		for _, ident := range idents {
+			if ident, ok := ident.(*astrav.Ident); ok && ident.Info().Defs[ident.Ident] != nil {
+				vars[ident.Info().Defs[ident.Ident]] = true
+			}
+		}
+	}
+	return vars
+}
+
+// sharedLoopVars checks if the loops of the file containing the node share their variables between
+// iterations. That is the case before Go 1.22 and for files of unknown Go version.
+func sharedLoopVars(node astrav.Node) bool {
+	var goVersion string
+	if file, ok := node.NextParentByType(astrav.NodeTypeFile).(*astrav.File); ok && node.Info() != nil {
+		goVersion = node.Info().FileVersions[file.File]
+	}
+	return version.Compare(goVersion, "go1.22") < 0
+}
+
+// Detect reports goroutines capturing loop variables in files before Go 1.22, Lock calls without
+// deferred Unlock and WaitGroup.Add calls inside goroutines. The diagnostics are sorted by position.
+func Detect(root astrav.Node) []*rules.Diagnostic {
+	var diags []*rules.Diagnostic
+	for _, g := range Goroutines(root) {
+		if !sharedLoopVars(g.Stmt) {
+			continue
+		}
+		for _, capture := range g.LoopVarCaptures {
+			msg := "goroutine captures loop variable " + capture.Var.Name() + ": pass it as argument"
+			diags = append(diags, detect.Diagnostic("loop-var-capture", rules.SeverityWarning, msg, g.Stmt))
+		}
+	}
+	for _, call := range LocksWithoutDeferredUnlock(root) {
+		msg := "Lock without deferred Unlock: use defer to unlock"
+		diags = append(diags, detect.Diagnostic("lock-without-defer", rules.SeverityInfo, msg, call))
+	}
+	for _, call := range WaitGroupAddInGoroutine(root) {
+		msg := "WaitGroup.Add inside the goroutine: call it before starting the goroutine"
+		diags = append(diags, detect.Diagnostic("waitgroup-add-in-goroutine", rules.SeverityWarning, msg, call))
+	}
+	rules.SortDiagnostics(diags)
+	return diags
+}
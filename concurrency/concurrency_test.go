@@ -0,0 +1,100 @@
+package concurrency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/astravtest"
+)
+
+func names(captures []*astrav.Capture) []string {
+	var res []string
+	for _, capture := range captures {
+		res = append(res, capture.Var.Name())
+	}
+	return res
+}
+
+func TestDetect(t *testing.T) {
+	pkg := astravtest.LoadPackage(t, "testdata/conc")
+
+	astravtest.Check(t, pkg, Detect(pkg))
+}
+
+func TestDetect_Locks(t *testing.T) {
+	pkg := astravtest.LoadPackage(t, "testdata/locks")
+
+	astravtest.Check(t, pkg, Detect(pkg))
+}
+
+func TestDetect_WaitGroups(t *testing.T) {
+	pkg := astravtest.LoadPackage(t, "testdata/waitgroups")
+
+	astravtest.Check(t, pkg, Detect(pkg))
+}
+
+func TestGoroutines(t *testing.T) {
+	pkg := astravtest.LoadPackage(t, "testdata/conc")
+
+	goroutines := Goroutines(pkg)
+	if !assert.Equal(t, 3, len(goroutines)) {
+		return
+	}
+
+	assert.Equal(t, astrav.NodeTypeFuncLit, goroutines[0].Func.NodeType())
+	assert.Equal(t, []string{"wg", "i", "jobs", "results"}, names(goroutines[0].Captures))
+	assert.Equal(t, []string{"i"}, names(goroutines[0].LoopVarCaptures))
+
+	assert.Equal(t, []string{"wg", "results"}, names(goroutines[1].Captures))
+	assert.Empty(t, goroutines[1].LoopVarCaptures)
+
+	assert.Equal(t, astrav.NodeTypeFuncDecl, goroutines[2].Func.NodeType())
+	assert.Empty(t, goroutines[2].Captures)
+}
+
+func TestChannels(t *testing.T) {
+	pkg := astravtest.LoadPackage(t, "testdata/conc")
+
+	channels := Channels(pkg)
+	if !assert.Equal(t, 5, len(channels)) {
+		return
+	}
+
+	type counts struct {
+		name                           string
+		makes, sends, receives, closes int
+	}
+	var res []counts
+	for _, ch := range channels {
+		res = append(res, counts{ch.Obj.Name(), len(ch.Makes), len(ch.Sends), len(ch.Receives), len(ch.Closes)})
+	}
+	assert.Equal(t, []counts{
+		{"jobs", 0, 0, 1, 0},
+		{"results", 0, 1, 0, 0},
+		{"jobs", 1, 1, 0, 1},
+		{"results", 1, 0, 1, 1},
+		{"done", 1, 0, 1, 1},
+	}, res)
+}
+
+func TestLocksWithoutDeferredUnlock(t *testing.T) {
+	pkg := astravtest.LoadPackage(t, "testdata/conc")
+
+	calls := LocksWithoutDeferredUnlock(pkg)
+	if assert.Equal(t, 1, len(calls)) {
+		assert.Equal(t, "c.Lock()", calls[0].GetSourceString())
+	}
+	assert.Equal(t, 1, len(WaitGroupAddInGoroutine(pkg)))
+}
+
+func TestDetect_LoopVarGoVersion(t *testing.T) {
+	pkg := astravtest.LoadPackage(t, "testdata/loopvar")
+
+	goroutines := Goroutines(pkg)
+	if assert.Equal(t, 2, len(goroutines)) {
+		assert.Equal(t, []string{"item"}, names(goroutines[0].LoopVarCaptures))
+		assert.Equal(t, []string{"item"}, names(goroutines[1].LoopVarCaptures))
+	}
+	astravtest.Check(t, pkg, Detect(pkg))
+}
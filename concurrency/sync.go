@@ -0,0 +1,91 @@
+package concurrency
+
+import (
+	"go/ast"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/internal/detect"
+)
+
+var unlocks = map[string]string{
+	"(*sync.Mutex).Lock":    "(*sync.Mutex).Unlock",
+	"(*sync.RWMutex).Lock":  "(*sync.RWMutex).Unlock",
+	"(*sync.RWMutex).RLock": "(*sync.RWMutex).RUnlock",
+}
+
+// LocksWithoutDeferredUnlock returns the Lock and RLock calls of sync.Mutex and sync.RWMutex without
+// a deferred Unlock of the same mutex in the same function.
+func LocksWithoutDeferredUnlock(root astrav.Node) []*astrav.CallExpr {
+	var res []*astrav.CallExpr
+	for _, node := range root.FindByNodeType(astrav.NodeTypeCallExpr) {
+		call := node.(*astrav.CallExpr)
+		callee := call.Callee()
+		if callee.Func == nil {
+			continue
+		}
+		unlock, ok := unlocks[callee.Func.FullName()]
+		if !ok {
+			continue
+		}
+
+		if !hasDeferredCall(detect.EnclosingFunc(call), unlock, receiverSource(call)) {
+			res = append(res, call)
+		}
+	}
+	return res
+}
+
+func hasDeferredCall(fn astrav.Node, fullName, recv string) bool {
+	if fn == nil {
+		return false
+	}
+	for _, node := range fn.FindByNodeType(astrav.NodeTypeDeferStmt) {
+		// calls deferred by function literals run when the literal returns
+		if detect.EnclosingFunc(node) != fn {
+			continue
+		}
+		call := node.(*astrav.DeferStmt).Call()
+		if detect.IsFunc(call, fullName) && receiverSource(call) == recv {
+			return true
+		}
+	}
+	return false
+}
+
+// receiverSource returns the source of the receiver of a method call.
+func receiverSource(call *astrav.CallExpr) string {
+	sel, ok := call.Fun().(*astrav.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	return sel.X().GetSourceString()
+}
+
+// WaitGroupAddInGoroutine returns the calls of sync.WaitGroup.Add within function literals started
+// as goroutines. Add has to be called before starting the goroutine to avoid racing with Wait.
+func WaitGroupAddInGoroutine(root astrav.Node) []*astrav.CallExpr {
+	var res []*astrav.CallExpr
+	for _, node := range root.FindByNodeType(astrav.NodeTypeCallExpr) {
+		call := node.(*astrav.CallExpr)
+		if !detect.IsFunc(call, "(*sync.WaitGroup).Add") {
+			continue
+		}
+		if inGoroutine(call) {
+			res = append(res, call)
+		}
+	}
+	return res
+}
+
+// inGoroutine checks if the node is within the function literal started by a go statement.
+func inGoroutine(node astrav.Node) bool {
+	fn, ok := detect.EnclosingFunc(node).(*astrav.FuncLit)
+	if !ok {
+		return false
+	}
+	call, ok := fn.Parent().(*astrav.CallExpr)
+	if !ok || call.CallExpr.Fun != ast.Expr(fn.FuncLit) {
+		return false
+	}
+	return call.Parent().IsNodeType(astrav.NodeTypeGoStmt)
+}
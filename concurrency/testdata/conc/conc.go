@@ -0,0 +1,76 @@
+package conc
+
+import (
+	"fmt"
+	"sync"
+)
+
+type counter struct {
+	sync.Mutex
+	counts map[string]int
+}
+
+func (c *counter) inc(key string) {
+	c.Lock() // want "Lock without deferred Unlock"
+	c.counts[key]++
+	c.Unlock()
+}
+
+func (c *counter) get(key string) int {
+	c.Lock()
+	defer c.Unlock()
+	return c.counts[key]
+}
+
+func worker(id int, jobs <-chan string, results chan<- string) {
+	for job := range jobs {
+		results <- fmt.Sprint(id, job)
+	}
+}
+
+func run(items []string) []string {
+	jobs := make(chan string, len(items))
+	results := make(chan string)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() { // want "goroutine captures loop variable i"
+			defer wg.Done()
+			worker(i, jobs, results)
+		}()
+	}
+
+	go func() {
+		wg.Add(1) // want "WaitGroup.Add inside the goroutine"
+		defer wg.Done()
+		wg.Wait()
+		close(results)
+	}()
+
+	for _, item := range items {
+		jobs <- item
+	}
+	close(jobs)
+
+	var out []string
+	for {
+		select {
+		case res, ok := <-results:
+			if !ok {
+				close(done)
+				return out
+			}
+			out = append(out, res)
+		case <-done:
+			return out
+		}
+	}
+}
+
+func start(c *counter, keys []string) {
+	for _, key := range keys {
+		go c.inc(key)
+	}
+}
@@ -0,0 +1,53 @@
+package locks
+
+import "sync"
+
+type store struct {
+	mu    sync.RWMutex
+	other sync.Mutex
+	data  map[string]string
+}
+
+func (s *store) get(key string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data[key]
+}
+
+func (s *store) set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+}
+
+func (s *store) mismatched(key string) string {
+	s.mu.RLock() // want "Lock without deferred Unlock"
+	defer s.mu.Unlock()
+	return s.data[key]
+}
+
+func (s *store) otherMutex(key string) {
+	s.mu.Lock() // want "Lock without deferred Unlock"
+	defer s.other.Unlock()
+	delete(s.data, key)
+}
+
+func (s *store) deferredInClosure(key string) {
+	s.mu.Lock() // want "Lock without deferred Unlock"
+	func() {
+		defer s.mu.Unlock()
+	}()
+	delete(s.data, key)
+}
+
+func (s *store) lockInClosure(keys []string) {
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		func() {
+			s.other.Lock() // want "Lock without deferred Unlock"
+			s.data[key] = ""
+			s.other.Unlock()
+		}()
+	}
+	s.mu.Lock()
+}
@@ -0,0 +1,11 @@
+//go:build go1.22
+
+package loopvar
+
+func current(items []string, out chan<- string) {
+	for _, item := range items {
+		go func() {
+			out <- item
+		}()
+	}
+}
@@ -0,0 +1,11 @@
+//go:build go1.21
+
+package loopvar
+
+func old(items []string, out chan<- string) {
+	for _, item := range items {
+		go func() { // want "captures loop variable item"
+			out <- item
+		}()
+	}
+}
@@ -0,0 +1,44 @@
+package waitgroups
+
+import "sync"
+
+func work(n int) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+		}()
+	}
+
+	go func() {
+		wg.Add(1) // want "WaitGroup.Add inside the goroutine"
+		defer wg.Done()
+	}()
+
+	go func() {
+		add := func() {
+			wg.Add(1)
+		}
+		add()
+	}()
+
+	add := func() {
+		wg.Add(1)
+	}
+	add()
+	go func() {
+		defer wg.Done()
+	}()
+
+	go spawn(&wg)
+	wg.Wait()
+}
+
+func spawn(wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Done()
+}
@@ -0,0 +1,103 @@
+package astrav
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+)
+
+// ConstValue returns the value of a constant expression. It returns nil if the node is not
+// a constant expression or no type information is available.
+func (s *baseNode) ConstValue() constant.Value {
+	expr, ok := s.node.(ast.Expr)
+	if !ok || s.Pkg() == nil || s.Info() == nil {
+		return nil
+	}
+	return s.Info().Types[expr].Value
+}
+
+// ConstInt returns the value of a constant expression as int64. The bool is false if the node is not
+// a constant or its value cannot be represented exactly as int64.
+func (s *baseNode) ConstInt() (int64, bool) {
+	val := s.realMe.ConstValue()
+	if val == nil {
+		return 0, false
+	}
+	val = constant.ToInt(val)
+	if val.Kind() != constant.Int {
+		return 0, false
+	}
+	return constant.Int64Val(val)
+}
+
+// ConstFloat returns the value of a numeric constant expression as float64. The bool is false if the node
+// is not a numeric constant.
+func (s *baseNode) ConstFloat() (float64, bool) {
+	val := s.realMe.ConstValue()
+	if val == nil {
+		return 0, false
+	}
+	val = constant.ToFloat(val)
+	if val.Kind() != constant.Float {
+		return 0, false
+	}
+	f, _ := constant.Float64Val(val)
+	return f, true
+}
+
+// ConstString returns the value of a constant string expression.
+func (s *baseNode) ConstString() (string, bool) {
+	val := s.realMe.ConstValue()
+	if val == nil || val.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(val), true
+}
+
+// ConstBool returns the value of a constant boolean expression.
+func (s *baseNode) ConstBool() (bool, bool) {
+	val := s.realMe.ConstValue()
+	if val == nil || val.Kind() != constant.Bool {
+		return false, false
+	}
+	return constant.BoolVal(val), true
+}
+
+// FindConstants finds all constant expressions. Only the outermost expression is returned if
+// constant expressions are nested, e.g. `2 * x` for a constant x.
+func (s *baseNode) FindConstants() []Node {
+	var nodes []Node
+	for _, child := range s.Children() {
+		child.Walk(func(node Node) bool {
+			if node.ConstValue() == nil {
+				return true
+			}
+			nodes = append(nodes, node)
+			return false
+		})
+	}
+	return nodes
+}
+
+// FindMagicNumbers finds numeric literals that are neither 0 nor 1 outside of constant declarations.
+func (s *baseNode) FindMagicNumbers() []Node {
+	return s.TreeNodes(func(n Node) bool {
+		lit, ok := n.(*BasicLit)
+		if !ok || !isNumber(lit.BasicLit.Kind) {
+			return false
+		}
+		if val, ok := lit.ConstFloat(); ok && (val == 0 || val == 1) {
+			return false
+		}
+		return !isConstDecl(lit)
+	})
+}
+
+func isNumber(kind token.Token) bool {
+	return kind == token.INT || kind == token.FLOAT || kind == token.IMAG
+}
+
+func isConstDecl(node Node) bool {
+	decl, ok := node.NextParentByType(NodeTypeGenDecl).(*GenDecl)
+	return ok && decl.GenDecl.Tok == token.CONST
+}
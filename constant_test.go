@@ -0,0 +1,74 @@
+package astrav
+
+import (
+	"go/constant"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseNode_ConstValue(t *testing.T) {
+	pkg := getPackage(t, 8)
+
+	scores := map[string]int64{}
+	for _, node := range pkg.FindByNodeType(NodeTypeKeyValueExpr) {
+		kv := node.(*KeyValueExpr)
+		key, ok := kv.Key().ConstString()
+		assert.True(t, ok)
+		value, ok := kv.Value().ConstInt()
+		assert.True(t, ok)
+		scores[key] = value
+	}
+	assert.Equal(t, map[string]int64{
+		"AEIOULNRSTaeioulnrst": 1,
+		"DGdg":                 2,
+		"BCMPbcmp":             3,
+		"FHVWYfhvwy":           4,
+		"Kk":                   5,
+		"JXjx":                 8,
+		"QZqz":                 10,
+	}, scores)
+
+	pkg = getPackage(t, 7)
+
+	minusOne := pkg.FindByToken(token.SUB)[0]
+	assert.Equal(t, constant.MakeInt64(-1), minusOne.ConstValue())
+	value, ok := minusOne.ConstFloat()
+	assert.True(t, ok)
+	assert.Equal(t, -1.0, value)
+	_, ok = minusOne.ConstString()
+	assert.False(t, ok)
+
+	ident := pkg.FindFirstIdentByName("diff")
+	assert.Nil(t, ident.ConstValue())
+	_, ok = ident.ConstInt()
+	assert.False(t, ok)
+
+	cond := pkg.FindFirstByNodeType(NodeTypeIfStmt).(*IfStmt).Cond()
+	_, ok = cond.ConstBool()
+	assert.False(t, ok)
+}
+
+func TestBaseNode_FindConstants(t *testing.T) {
+	pkg := getPackage(t, 7)
+
+	var values []string
+	for _, node := range pkg.FindConstants() {
+		values = append(values, node.ConstValue().ExactString())
+	}
+	assert.Equal(t, []string{`"strings have different length"`, "5", "3", "4", "3", "-1", "0", "0", "5"}, values)
+}
+
+func TestBaseNode_FindMagicNumbers(t *testing.T) {
+	pkg := getPackage(t, 7)
+
+	var values []string
+	for _, node := range pkg.FindMagicNumbers() {
+		values = append(values, node.GetSourceString())
+	}
+	assert.Equal(t, []string{"4"}, values)
+
+	pkg = getPackage(t, 8)
+	assert.Equal(t, 6, len(pkg.FindMagicNumbers()))
+}
@@ -0,0 +1,65 @@
+package astrav
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// processDependencies wraps all dependencies selected by Dependencies as Packages.
+func (s *Module) processDependencies() error {
+	s.Deps = map[string]*Package{}
+	if len(s.Dependencies) == 0 {
+		return nil
+	}
+
+	roots := make(map[*packages.Package]bool, len(s.Packages))
+	for _, pack := range s.Packages {
+		roots[pack] = true
+	}
+
+	var deps []*packages.Package
+	packages.Visit(s.Packages, nil, func(pack *packages.Package) {
+		if !roots[pack] && s.isSelectedDependency(pack.PkgPath) {
+			deps = append(deps, pack)
+		}
+	})
+
+	for _, pack := range deps {
+		if pack.TypesInfo == nil {
+			return errors.Errorf("dependency %s was loaded without source", pack.PkgPath)
+		}
+		for i, fileName := range pack.CompiledGoFiles {
+			if _, ok := s.RawFiles[fileName]; ok || i >= len(pack.Syntax) {
+				continue
+			}
+			src, err := os.ReadFile(fileName)
+			if err != nil {
+				return errors.WithMessagef(err, "failed reading dependency %s", pack.PkgPath)
+			}
+			s.RawFiles[fileName] = NewRawFile(s.FSet.File(pack.Syntax[i].Pos()), src)
+		}
+
+		pkgNode, err := s.newPackage(pack)
+		if err != nil {
+			return err
+		}
+		s.Deps[pack.PkgPath] = pkgNode
+	}
+	return nil
+}
+
+func (s *Module) isSelectedDependency(pkgPath string) bool {
+	for _, pattern := range s.Dependencies {
+		if pattern == "..." || pattern == pkgPath {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/..."); ok &&
+			(pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package astrav
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModule_Dependencies(t *testing.T) {
+	dir, err := filepath.Abs("testdata/deps/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	module := NewModule(dir)
+	module.Dependencies = []string{"example.com/lib/..."}
+	module.ResolveCallees = true
+	module.CacheDir = t.TempDir()
+	if r := module.Load(); r != nil {
+		t.Fatal(r)
+	}
+
+	assert.Len(t, module.Pkgs, 1)
+	assert.Len(t, module.Deps, 1)
+	strutil := module.Package("example.com/lib/strutil")
+	if !assert.NotNil(t, strutil) {
+		return
+	}
+	assert.Contains(t, string(strutil.GetSource()), "func upper(s string) string")
+
+	greeting := module.Package("example.com/app").FuncDeclByName("Greeting")
+	call := greeting.FindFirstByNodeType(NodeTypeCallExpr).(*CallExpr)
+	assert.Equal(t, strutil.FuncDeclByName("Reverse"), call.Callee().Decl)
+
+	toUpper := greeting.FindNameInCallTree("ToUpper")
+	if assert.Len(t, toUpper, 1) {
+		pos := toUpper[0].Position()
+		assert.Equal(t, "strutil.go", filepath.Base(pos.Filename))
+		assert.Equal(t, 15, pos.Line)
+	}
+
+	entries, err := os.ReadDir(module.CacheDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestModule_isSelectedDependency(t *testing.T) {
+	module := NewModule("")
+	module.Dependencies = []string{"strings", "example.com/lib/..."}
+
+	assert.True(t, module.isSelectedDependency("strings"))
+	assert.True(t, module.isSelectedDependency("example.com/lib"))
+	assert.True(t, module.isSelectedDependency("example.com/lib/strutil"))
+	assert.False(t, module.isSelectedDependency("example.com/library"))
+	assert.False(t, module.isSelectedDependency("fmt"))
+}
@@ -0,0 +1,197 @@
+// Package dump renders astrav trees and call graphs in human readable formats. It is meant as a
+// debugging aid for rule authors to see exactly what the astrav wrappers produce.
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/tehsphinx/astrav"
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/ssa"
+)
+
+// Text writes an indented representation of the tree below root to w. Every line contains the
+// node type followed by name, token, value type and position where available.
+func Text(w io.Writer, root astrav.Node) error {
+	var buf bytes.Buffer
+	base := root.Level()
+	root.Walk(func(node astrav.Node) bool {
+		buf.WriteString(strings.Repeat("\t", node.Level()-base))
+		buf.WriteString(Describe(node))
+		buf.WriteByte('\n')
+		return true
+	})
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// DOT writes the tree below root as a Graphviz digraph to w.
+func DOT(w io.Writer, root astrav.Node) error {
+	var (
+		buf bytes.Buffer
+		ids = map[astrav.Node]int{}
+	)
+
+	buf.WriteString("digraph astrav {\n")
+	buf.WriteString("\tnode [shape=box, fontname=\"monospace\"];\n")
+	root.Walk(func(node astrav.Node) bool {
+		id := len(ids)
+		ids[node] = id
+
+		fmt.Fprintf(&buf, "\tn%d [label=%q];\n", id, label(node))
+		if parent, ok := ids[node.Parent()]; ok && node != root {
+			fmt.Fprintf(&buf, "\tn%d -> n%d;\n", parent, id)
+		}
+		return true
+	})
+	buf.WriteString("}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// CallGraphDOT writes the given call graph as a Graphviz digraph to w. The filter decides which
+// functions are included. If filter is nil all functions of the graph are written.
+func CallGraphDOT(w io.Writer, graph *callgraph.Graph, filter func(fn *ssa.Function) bool) error {
+	if filter == nil {
+		filter = func(fn *ssa.Function) bool { return true }
+	}
+
+	nodes := make([]*callgraph.Node, 0, len(graph.Nodes))
+	for fn, node := range graph.Nodes {
+		if fn == nil || !filter(fn) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString("digraph callgraph {\n")
+	buf.WriteString("\tnode [shape=box, fontname=\"monospace\"];\n")
+	for _, node := range nodes {
+		fmt.Fprintf(&buf, "\tf%d [label=%q];\n", node.ID, node.Func.String())
+	}
+
+	seen := map[[2]int]bool{}
+	for _, node := range nodes {
+		for _, edge := range node.Out {
+			if edge.Callee.Func == nil || !filter(edge.Callee.Func) {
+				continue
+			}
+			key := [2]int{node.ID, edge.Callee.ID}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			fmt.Fprintf(&buf, "\tf%d -> f%d;\n", node.ID, edge.Callee.ID)
+		}
+	}
+	buf.WriteString("}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// ModuleFilter returns a filter for CallGraphDOT that only keeps functions declared in one of
+// the packages of the given module.
+func ModuleFilter(module *astrav.Module) func(fn *ssa.Function) bool {
+	return func(fn *ssa.Function) bool {
+		if fn.Pkg == nil {
+			return false
+		}
+		_, ok := module.Pkgs[fn.Pkg.Pkg.Path()]
+		return ok
+	}
+}
+
+// Describe returns a single line description of a node containing its node type, name, token,
+// value type and position where available.
+func Describe(node astrav.Node) string {
+	parts := []string{label(node)}
+
+	if t := valueType(node); t != nil && t != types.Typ[types.Invalid] {
+		parts = append(parts, "type="+t.String())
+	}
+	if pos := node.Position(); pos.IsValid() {
+		parts = append(parts, "pos="+pos.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+func label(node astrav.Node) string {
+	parts := []string{strings.TrimPrefix(string(node.NodeType()), "*astrav.")}
+
+	if named, ok := node.(astrav.Named); ok {
+		if name := named.NodeName(); name != "" {
+			parts = append(parts, "name="+name)
+		}
+	}
+	if tok, ok := node.(astrav.Token); ok {
+		parts = append(parts, "tok="+tok.Token().String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// valueType guards against nodes that were created without type information.
+func valueType(node astrav.Node) types.Type {
+	if node.Pkg() == nil || node.Info() == nil {
+		return nil
+	}
+	return node.ValueType()
+}
+
+// Info is a serializable description of a node used by JSON.
+type Info struct {
+	Type      string  `json:"type"`
+	Name      string  `json:"name,omitempty"`
+	Token     string  `json:"token,omitempty"`
+	ValueType string  `json:"valueType,omitempty"`
+	Pos       string  `json:"pos,omitempty"`
+	Children  []*Info `json:"children,omitempty"`
+}
+
+// NodeInfo returns the description of a single node without its children.
+func NodeInfo(node astrav.Node) *Info {
+	info := &Info{
+		Type: strings.TrimPrefix(string(node.NodeType()), "*astrav."),
+	}
+	if named, ok := node.(astrav.Named); ok {
+		info.Name = named.NodeName()
+	}
+	if tok, ok := node.(astrav.Token); ok {
+		info.Token = tok.Token().String()
+	}
+	if t := valueType(node); t != nil && t != types.Typ[types.Invalid] {
+		info.ValueType = t.String()
+	}
+	if pos := node.Position(); pos.IsValid() {
+		info.Pos = pos.String()
+	}
+	return info
+}
+
+// JSON writes the tree below root as nested JSON objects to w.
+func JSON(w io.Writer, root astrav.Node) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(tree(root))
+}
+
+func tree(node astrav.Node) *Info {
+	info := NodeInfo(node)
+	for _, child := range node.Children() {
+		info.Children = append(info.Children, tree(child))
+	}
+	return info
+}
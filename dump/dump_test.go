@@ -0,0 +1,91 @@
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tehsphinx/astrav"
+)
+
+func TestText(t *testing.T) {
+	pkg := getPackage(t, "../example/1")
+
+	var buf bytes.Buffer
+	err := Text(&buf, pkg.(*astrav.Package).FuncDeclByName("valor"))
+	assert.NoError(t, err)
+
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, "FuncDecl name=valor pos=example.go:16:1", lines[0])
+	assert.Equal(t, "\tIdent name=valor type=func(char byte) int pos=example.go:16:6", lines[1])
+	assert.Contains(t, buf.String(), "\t\t\t\tSelectorExpr name=strings.ToLower type=func(s string) string pos=example.go:17:9\n")
+	assert.Contains(t, buf.String(), "\t\t\t\t\tIdent name=strings pos=example.go:17:9\n")
+}
+
+func TestDOT(t *testing.T) {
+	pkg := getPackage(t, "../example/6")
+
+	var buf bytes.Buffer
+	err := DOT(&buf, pkg.FindFirstByNodeType(astrav.NodeTypeIfStmt))
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "digraph astrav {\n"))
+	assert.Contains(t, out, "\tn0 [label=\"IfStmt\"];\n")
+	assert.Contains(t, out, "\tn1 [label=\"BinaryExpr tok===\"];\n")
+	assert.Contains(t, out, "\tn0 -> n1;\n")
+	assert.True(t, strings.HasSuffix(out, "}\n"))
+}
+
+func TestCallGraphDOT(t *testing.T) {
+	dir, err := filepath.Abs("../example/5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	module := astrav.NewModule(dir)
+	if r := module.Load(); r != nil {
+		t.Fatal(r)
+	}
+
+	var buf bytes.Buffer
+	err = CallGraphDOT(&buf, module.Graph, ModuleFilter(module))
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "example/5.Difference\"];")
+	assert.Contains(t, out, "example/5.SumOfSquares\"];")
+	assert.NotContains(t, out, "math.Pow")
+	assert.Equal(t, 3, strings.Count(out, "->"))
+}
+
+func getPackage(t *testing.T, path string) astrav.Node {
+	folder := astrav.NewFolder(http.Dir(path), "")
+	pkgs, err := folder.ParseFolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range pkgs {
+		return pkg
+	}
+	return nil
+}
+
+func TestJSON(t *testing.T) {
+	pkg := getPackage(t, "../example/6")
+
+	var buf bytes.Buffer
+	err := JSON(&buf, pkg.FindFirstByNodeType(astrav.NodeTypeReturnStmt))
+	assert.NoError(t, err)
+
+	var info Info
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &info))
+	assert.Equal(t, "ReturnStmt", info.Type)
+	assert.Equal(t, "example.go:10:2", info.Pos)
+	assert.Equal(t, 1, len(info.Children))
+	assert.Equal(t, "CallExpr", info.Children[0].Type)
+	assert.Equal(t, "string", info.Children[0].ValueType)
+}
@@ -0,0 +1,41 @@
+// Package errcheck analyzes error handling: ignored errors, shadowed err variables, errors returned
+// without wrapping, comparisons of errors with == and custom error types with receiver mismatches.
+// The detectors return rules.Diagnostics pointing to the matching node.
+//
+//	diags := errcheck.Detect(pkg)
+package errcheck
+
+import (
+	"go/types"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/internal/detect"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+// Detector detects an error handling issue within the tree of the root node.
+type Detector = detect.Detector
+
+// Detectors are all detectors of the package.
+var Detectors = []Detector{
+	IgnoredErrors,
+	ShadowedErr,
+	UnwrappedReturns,
+	ErrorComparison,
+	ReceiverMismatches,
+}
+
+// Detect runs all detectors. The diagnostics are sorted by position.
+func Detect(root astrav.Node) []*rules.Diagnostic {
+	return detect.Run(root, Detectors)
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+func isError(t types.Type) bool {
+	return t != nil && types.Identical(t, errorType)
+}
+
+func implementsError(t types.Type) bool {
+	return types.Implements(t, errorType.Underlying().(*types.Interface))
+}
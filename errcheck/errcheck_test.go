@@ -0,0 +1,38 @@
+package errcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tehsphinx/astrav/astravtest"
+)
+
+func TestDetect(t *testing.T) {
+	pkg := astravtest.LoadPackage(t, "testdata/errs")
+
+	diags := Detect(pkg)
+	astravtest.Check(t, pkg, diags)
+}
+
+func TestDetectors(t *testing.T) {
+	tests := []struct {
+		dir    string
+		detect Detector
+	}{
+		{dir: "testdata/ignored", detect: IgnoredErrors},
+		{dir: "testdata/shadow", detect: ShadowedErr},
+		{dir: "testdata/unwrapped", detect: UnwrappedReturns},
+		{dir: "testdata/comparison", detect: ErrorComparison},
+		{dir: "testdata/receivers", detect: ReceiverMismatches},
+	}
+	for _, tt := range tests {
+		pkg := astravtest.LoadPackage(t, tt.dir)
+		astravtest.Check(t, pkg, tt.detect(pkg))
+	}
+}
+
+func TestDetect_Hamming(t *testing.T) {
+	pkg := astravtest.LoadPackage(t, "../example/7")
+
+	assert.Empty(t, Detect(pkg))
+}
@@ -0,0 +1,122 @@
+package errcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/internal/detect"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+// ShadowedErr detects declarations of err variables shadowing an err variable of an outer scope
+// within the same function. Function literals are separate functions: an err declared in a closure
+// does not shadow the err of the enclosing function.
+func ShadowedErr(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "shadowed-err", rules.SeverityWarning, func(node astrav.Node) string {
+		ident, ok := node.(*astrav.Ident)
+		if !ok || ident.Name != "err" || ident.Info() == nil {
+			return ""
+		}
+		v, ok := ident.Info().Defs[ident.Ident].(*types.Var)
+		if !ok || v.Parent() == nil || v.Parent().Parent() == nil || !isError(v.Type()) {
+			return ""
+		}
+
+		_, outer := v.Parent().Parent().LookupParent("err", v.Pos())
+		outerVar, ok := outer.(*types.Var)
+		if !ok || !isError(outerVar.Type()) || isPackageLevel(outerVar) {
+			return ""
+		}
+		if fn := detect.EnclosingFunc(ident); fn == nil || !detect.Declares(fn, outerVar) {
+			return ""
+		}
+
+		msg := "declaration of err shadows err declared in an outer scope"
+		if decl := ident.Pkg().DeclarationOf(outerVar); decl != nil {
+			msg = "declaration of err shadows err declared at " + decl.Position().String()
+		}
+		return msg
+	})
+}
+
+func isPackageLevel(obj types.Object) bool {
+	return obj.Parent() == nil || obj.Parent() == types.Universe ||
+		obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope()
+}
+
+// UnwrappedReturns detects errors returned unchanged after checking them, e.g.
+// `if err != nil { return err }`. Wrapping the error with fmt.Errorf("...: %w", err) adds context.
+func UnwrappedReturns(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "unwrapped-error", rules.SeverityInfo, func(node astrav.Node) string {
+		ret, ok := node.(*astrav.ReturnStmt)
+		if !ok {
+			return ""
+		}
+
+		for _, result := range ret.Results() {
+			obj, ok := detect.ObjectOf(result).(*types.Var)
+			if !ok || !isError(obj.Type()) || isPackageLevel(obj) {
+				continue
+			}
+			if checkedNotNil(ret, obj) {
+				return "error returned without wrapping: add context with fmt.Errorf(\"...: %w\", " + obj.Name() + ")"
+			}
+		}
+		return ""
+	})
+}
+
+// checkedNotNil checks if the node is within the body of an if statement checking `v != nil`.
+func checkedNotNil(node astrav.Node, v *types.Var) bool {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch p := parent.(type) {
+		case *astrav.FuncDecl, *astrav.FuncLit:
+			return false
+		case *astrav.IfStmt:
+			bin, ok := p.Cond().(*astrav.BinaryExpr)
+			if !ok || bin.BinaryExpr.Op != token.NEQ || !p.Body().Contains(node) {
+				continue
+			}
+			x, y := ast.Unparen(bin.BinaryExpr.X), ast.Unparen(bin.BinaryExpr.Y)
+			if isVar(p, x, v) && isNil(y) || isVar(p, y, v) && isNil(x) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isVar(node astrav.Node, expr ast.Expr, v *types.Var) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && node.Info().ObjectOf(ident) == v
+}
+
+func isNil(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
+
+// ErrorComparison detects comparisons of errors with == or != instead of errors.Is.
+// Comparisons with nil are not reported.
+func ErrorComparison(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "error-comparison", rules.SeverityWarning, func(node astrav.Node) string {
+		bin, ok := node.(*astrav.BinaryExpr)
+		if !ok || bin.BinaryExpr.Op != token.EQL && bin.BinaryExpr.Op != token.NEQ {
+			return ""
+		}
+		if isNil(ast.Unparen(bin.BinaryExpr.X)) || isNil(ast.Unparen(bin.BinaryExpr.Y)) {
+			return ""
+		}
+
+		x, y := bin.X().ValueType(), bin.Y().ValueType()
+		if x == nil || y == nil || !isError(x) && !isError(y) || !implementsError(x) || !implementsError(y) {
+			return ""
+		}
+		if bin.BinaryExpr.Op == token.NEQ {
+			return "use !errors.Is to compare errors: wrapped errors do not match with !="
+		}
+		return "use errors.Is to compare errors: wrapped errors do not match with =="
+	})
+}
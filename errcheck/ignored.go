@@ -0,0 +1,92 @@
+package errcheck
+
+import (
+	"go/types"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/internal/detect"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+// IgnoredByDefault are functions whose errors are ignored by convention. They are not reported
+// by IgnoredErrors.
+var IgnoredByDefault = map[string]bool{
+	"fmt.Print":                      true,
+	"fmt.Printf":                     true,
+	"fmt.Println":                    true,
+	"fmt.Fprint":                     true,
+	"fmt.Fprintf":                    true,
+	"fmt.Fprintln":                   true,
+	"(*strings.Builder).Write":       true,
+	"(*strings.Builder).WriteByte":   true,
+	"(*strings.Builder).WriteRune":   true,
+	"(*strings.Builder).WriteString": true,
+	"(*bytes.Buffer).Write":          true,
+	"(*bytes.Buffer).WriteByte":      true,
+	"(*bytes.Buffer).WriteRune":      true,
+	"(*bytes.Buffer).WriteString":    true,
+}
+
+// IgnoredErrors detects calls returning an error whose result is discarded, either by calling
+// the function as a statement or by assigning the error to the blank identifier.
+// Functions of IgnoredByDefault are not reported.
+func IgnoredErrors(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "ignored-error", rules.SeverityWarning, func(node astrav.Node) string {
+		switch n := node.(type) {
+		case *astrav.ExprStmt:
+			call, ok := n.X().(*astrav.CallExpr)
+			if !ok || errorResult(call) < 0 {
+				return ""
+			}
+			return ignoredMessage(call)
+		case *astrav.AssignStmt:
+			if len(n.RHS()) != 1 {
+				return ""
+			}
+			call, ok := n.RHS()[0].(*astrav.CallExpr)
+			if !ok {
+				return ""
+			}
+			idx := errorResult(call)
+			if idx < 0 || len(n.LHS()) <= idx {
+				return ""
+			}
+			if lhs, ok := n.LHS()[idx].(*astrav.Ident); !ok || lhs.Name != "_" {
+				return ""
+			}
+			return ignoredMessage(call)
+		}
+		return ""
+	})
+}
+
+func ignoredMessage(call *astrav.CallExpr) string {
+	if callee := call.Callee(); callee.Func != nil && IgnoredByDefault[callee.Func.FullName()] {
+		return ""
+	}
+	return "error returned by " + call.Fun().GetSourceString() + " is ignored"
+}
+
+// errorResult returns the index of the error result of the called function, -1 if it returns no error.
+func errorResult(call *astrav.CallExpr) int {
+	callee := call.Callee()
+	if callee.Kind == astrav.CallBuiltin || callee.Kind == astrav.CallConversion {
+		return -1
+	}
+
+	var results *types.Tuple
+	switch t := call.ValueType().(type) {
+	case *types.Tuple:
+		results = t
+	case nil:
+		return -1
+	default:
+		results = types.NewTuple(types.NewVar(0, nil, "", t))
+	}
+	for i := results.Len() - 1; i >= 0; i-- {
+		if isError(results.At(i).Type()) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,97 @@
+package errcheck
+
+import (
+	"go/token"
+	"go/types"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/internal/detect"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+// ReceiverMismatches detects custom error types with pointer vs value receiver mismatches:
+// error types mixing pointer and value receivers, pointers to error types with value receiver
+// returned as error, and errors.As targets not implementing error.
+func ReceiverMismatches(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "error-receiver", rules.SeverityWarning, func(node astrav.Node) string {
+		switch n := node.(type) {
+		case *astrav.TypeSpec:
+			return mixedReceivers(n)
+		case *astrav.ReturnStmt:
+			return pointerToValueError(n)
+		case *astrav.CallExpr:
+			return asTarget(n)
+		}
+		return ""
+	})
+}
+
+func mixedReceivers(spec *astrav.TypeSpec) string {
+	t := spec.NamedType()
+	if t == nil || types.IsInterface(t) || !implementsError(types.NewPointer(t)) {
+		return ""
+	}
+
+	var pointer, value bool
+	for _, method := range spec.Methods() {
+		if method.PointerReceiver() {
+			pointer = true
+		} else {
+			value = true
+		}
+	}
+	if !pointer || !value {
+		return ""
+	}
+	return "error type " + spec.NodeName() + " mixes pointer and value receivers"
+}
+
+func pointerToValueError(ret *astrav.ReturnStmt) string {
+	sig := enclosingSignature(ret)
+	if sig == nil || len(sig.Results) != len(ret.Results()) {
+		return ""
+	}
+
+	for i, result := range ret.Results() {
+		unary, ok := result.(*astrav.UnaryExpr)
+		if !ok || unary.UnaryExpr.Op != token.AND || !isError(sig.Results[i].Type) {
+			continue
+		}
+		t := unary.X().ValueType()
+		if t == nil || !implementsError(t) {
+			continue
+		}
+		name := types.TypeString(t, types.RelativeTo(unary.Pkg().Types()))
+		return name + " implements error with a value receiver: return " + name + " instead of a pointer"
+	}
+	return ""
+}
+
+func enclosingSignature(node astrav.Node) *astrav.Signature {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch p := parent.(type) {
+		case *astrav.FuncDecl:
+			return p.Signature()
+		case *astrav.FuncLit:
+			return p.Signature()
+		}
+	}
+	return nil
+}
+
+func asTarget(call *astrav.CallExpr) string {
+	if !detect.IsFunc(call, "errors.As") || len(call.Args()) != 2 {
+		return ""
+	}
+	unary, ok := call.Args()[1].(*astrav.UnaryExpr)
+	if !ok || unary.UnaryExpr.Op != token.AND {
+		return ""
+	}
+
+	t := unary.X().ValueType()
+	if t == nil || types.IsInterface(t) || implementsError(t) {
+		return ""
+	}
+	name := types.TypeString(t, types.RelativeTo(unary.Pkg().Types()))
+	return "errors.As target " + name + " does not implement error: use *" + name
+}
@@ -0,0 +1,31 @@
+package comparison
+
+import (
+	"errors"
+	"io"
+)
+
+var errLimit = errors.New("limit")
+
+// LimitError is a custom error type.
+type LimitError struct{}
+
+func (*LimitError) Error() string {
+	return "limit"
+}
+
+func compare(err error, limitErr *LimitError) bool {
+	switch {
+	case err == io.EOF: // want "use errors.Is"
+	case io.EOF == err: // want "use errors.Is"
+	case err != errLimit: // want "use !errors.Is"
+	case (err) == (errLimit): // want "use errors.Is"
+	case err == limitErr: // want "use errors.Is"
+	case err == nil, nil != err, (err) == nil:
+	case limitErr == nil:
+	case errors.Is(err, io.EOF):
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+var errNotFound = errors.New("not found")
+
+// ValueError has a value receiver but is returned as pointer.
+type ValueError struct {
+	Value string
+}
+
+func (e ValueError) Error() string {
+	return "invalid value " + e.Value
+}
+
+// MixedError uses pointer and value receivers.
+type MixedError struct { // want "mixes pointer and value receivers"
+	Code int
+}
+
+func (e *MixedError) Error() string {
+	return strconv.Itoa(e.Code)
+}
+
+// Unwrap returns the wrapped error.
+func (e MixedError) Unwrap() error {
+	return nil
+}
+
+func parse(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err // want "returned without wrapping"
+	}
+	if n < 0 {
+		return 0, &ValueError{Value: s} // want "ValueError implements error with a value receiver"
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("parsing %q: %w", s, errNotFound)
+	}
+	return n, nil
+}
+
+func run(args []string) error {
+	os.Remove("tmp")             // want "error returned by os.Remove is ignored"
+	_, _ = strconv.Atoi(args[0]) // want "error returned by strconv.Atoi is ignored"
+	n, _ := parse(args[0])       // want "error returned by parse is ignored"
+	fmt.Println(n)
+
+	err := os.Setenv("A", "B")
+	if err != nil {
+		return fmt.Errorf("setting env: %w", err)
+	}
+
+	for _, arg := range args {
+		n, err := parse(arg)    // want "shadows err declared"
+		if err == errNotFound { // want "use errors.Is"
+			continue
+		}
+		if err != nil {
+			return err // want "returned without wrapping"
+		}
+		fmt.Println(n)
+	}
+
+	var target MixedError
+	if errors.As(err, &target) { // want "errors.As target MixedError does not implement error"
+		return nil
+	}
+	var ptrTarget *MixedError
+	if errors.As(err, &ptrTarget) {
+		return nil
+	}
+	return err
+}
+
+func retry(fn func() error) error {
+	err := fn()
+	again := func() error {
+		err := fn()
+		return err
+	}
+	if err == nil {
+		return nil
+	}
+	return again()
+}
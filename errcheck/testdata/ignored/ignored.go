@@ -0,0 +1,30 @@
+package ignored
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type closer struct{}
+
+func (closer) Close() error {
+	return nil
+}
+
+func calls(c closer, sb *strings.Builder) {
+	os.Remove("tmp")          // want "error returned by os.Remove is ignored"
+	c.Close()                 // want "error returned by c.Close is ignored"
+	_ = c.Close()             // want "error returned by c.Close is ignored"
+	_, _ = strconv.Atoi("1")  // want "error returned by strconv.Atoi is ignored"
+	n, _ := strconv.Atoi("2") // want "error returned by strconv.Atoi is ignored"
+	_, err := strconv.Atoi("3")
+	func() error { return nil }() // want "is ignored"
+
+	fmt.Println(n, err)
+	fmt.Fprintf(sb, "%d", n)
+	sb.WriteString("done")
+	_ = error(nil)
+	_ = len("x")
+}
@@ -0,0 +1,70 @@
+package receivers
+
+import "errors"
+
+// ValueError has a value receiver.
+type ValueError struct{}
+
+func (ValueError) Error() string {
+	return "value"
+}
+
+// PointerError has a pointer receiver.
+type PointerError struct{}
+
+func (*PointerError) Error() string {
+	return "pointer"
+}
+
+// MixedError uses pointer and value receivers.
+type MixedError struct{} // want "mixes pointer and value receivers"
+
+func (*MixedError) Error() string {
+	return "mixed"
+}
+
+// Unwrap returns the wrapped error.
+func (MixedError) Unwrap() error {
+	return nil
+}
+
+// Printer is not an error.
+type Printer struct{}
+
+func (*Printer) Print() {}
+
+// String is a value method.
+func (Printer) String() string {
+	return ""
+}
+
+func returns(kind int) error {
+	switch kind {
+	case 0:
+		return &ValueError{} // want "ValueError implements error with a value receiver"
+	case 1:
+		return &PointerError{}
+	case 2:
+		return ValueError{}
+	}
+	return nil
+}
+
+func nonError() *ValueError {
+	return &ValueError{}
+}
+
+func closure() func() error {
+	return func() error {
+		return &ValueError{} // want "ValueError implements error with a value receiver"
+	}
+}
+
+func targets(err error) bool {
+	var value ValueError
+	var pointer *PointerError
+	var wrong PointerError
+	var iface interface{ Timeout() bool }
+	return errors.As(err, &value) || errors.As(err, &pointer) || errors.As(err, &iface) ||
+		errors.As(err, &wrong) // want "errors.As target PointerError does not implement error"
+}
@@ -0,0 +1,59 @@
+package shadow
+
+import (
+	"errors"
+	"os"
+)
+
+var err = errors.New("package level")
+
+func nested(names []string) error {
+	err := os.Chdir("/")
+	for _, name := range names {
+		err := os.Remove(name) // want "shadows err declared"
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := os.Stat("x"); err != nil { // want "shadows err declared"
+		return err
+	}
+	return err
+}
+
+func topLevel() error {
+	err := os.Chdir("/")
+	return err
+}
+
+func closure() error {
+	err := os.Chdir("/")
+	check := func() error {
+		err := os.Chdir("/tmp")
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	return check()
+}
+
+func inClosure() func() error {
+	return func() error {
+		err := os.Chdir("/")
+		if err == nil {
+			err := os.Chdir("/tmp") // want "shadows err declared"
+			return err
+		}
+		return err
+	}
+}
+
+func notAnError() error {
+	err := os.Chdir("/")
+	{
+		err := "message"
+		_ = err
+	}
+	return err
+}
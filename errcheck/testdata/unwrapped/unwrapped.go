@@ -0,0 +1,39 @@
+package unwrapped
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+var errEmpty = errors.New("empty")
+
+func returns(name string) (int, error) {
+	if err := os.Remove(name); err != nil {
+		return 0, err // want "returned without wrapping"
+	}
+	err := os.Chdir(name)
+	if nil != err {
+		return 0, err // want "returned without wrapping"
+	}
+	if err != nil {
+		return 0, fmt.Errorf("chdir %s: %w", name, err)
+	}
+	if name == "" {
+		return 0, errEmpty
+	}
+	if err == nil {
+		return 1, err
+	}
+	return 0, err
+}
+
+func closure(name string) func() error {
+	err := os.Chdir(name)
+	if err != nil {
+		return func() error {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Package signatures contains functions with various signatures.
+package signatures
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Separator is implemented by types with a configurable separator.
+type Separator interface {
+	SetSep(sep string)
+}
+
+// Joiner joins strings.
+type Joiner struct {
+	sep string
+}
+
+// Join joins the given parts with the separator of the joiner.
+func (j Joiner) Join(parts ...string) string {
+	return strings.Join(parts, j.sep)
+}
+
+// SetSep sets the separator.
+func (j *Joiner) SetSep(sep string) {
+	j.sep = sep
+}
+
+// Parse parses all numbers. The first invalid number is returned with its error.
+func Parse(values []string) (numbers []int, invalid string, err error) {
+	convert := func(value string) (int, error) {
+		return strconv.Atoi(strings.TrimSpace(value))
+	}
+
+	for _, value := range values {
+		number, err := convert(value)
+		if err != nil {
+			return nil, value, err
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, "", nil
+}
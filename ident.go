@@ -20,18 +20,18 @@ func (s *FuncDecl) GetIdent() *Ident {
 
 // GetIdent returns the name of the node
 func (s *LabeledStmt) GetIdent() *Ident {
-	if s.Label == nil {
+	if s.LabeledStmt.Label == nil {
 		return nil
 	}
-	return s.findChildByAstNode(s.Label).(*Ident)
+	return s.findChildByAstNode(s.LabeledStmt.Label).(*Ident)
 }
 
 // GetIdent returns the name of the node
 func (s *BranchStmt) GetIdent() *Ident {
-	if s.Label == nil {
+	if s.BranchStmt.Label == nil {
 		return nil
 	}
-	return s.findChildByAstNode(s.Label).(*Ident)
+	return s.findChildByAstNode(s.BranchStmt.Label).(*Ident)
 }
 
 // GetIdent returns the name of the node
@@ -52,10 +52,10 @@ func (s *File) GetIdent() *Ident {
 
 // GetIdent returns the name of the node
 func (s *SelectorExpr) GetIdent() *Ident {
-	if s.Sel == nil {
+	if s.SelectorExpr.Sel == nil {
 		return nil
 	}
-	return s.findChildByAstNode(s.Sel).(*Ident)
+	return s.findChildByAstNode(s.SelectorExpr.Sel).(*Ident)
 }
 
 // GetIdent returns the name of the node
@@ -68,10 +68,10 @@ func (s *TypeSpec) GetIdent() *Ident {
 
 // GetIdent returns the name of the node
 func (s *CallExpr) GetIdent() *Ident {
-	if s.Fun == nil {
+	if s.CallExpr.Fun == nil {
 		return nil
 	}
-	switch t := s.Fun.(type) {
+	switch t := s.CallExpr.Fun.(type) {
 	case *ast.Ident:
 		return s.findChildByAstNode(t).(*Ident)
 	case *ast.ArrayType:
@@ -88,18 +88,18 @@ func (s *Ident) GetIdent() *Ident {
 
 // GetIdent returns the name of the node
 func (s *Field) GetIdent() *Ident {
-	if len(s.Names) == 0 {
+	if len(s.Field.Names) == 0 {
 		return nil
 	}
-	return s.findChildByAstNode(s.Names[0]).(*Ident)
+	return s.findChildByAstNode(s.Field.Names[0]).(*Ident)
 }
 
 // GetIdent returns the name of the node
 func (s *ArrayType) GetIdent() *Ident {
-	if s.Elt == nil {
+	if s.ArrayType.Elt == nil {
 		return nil
 	}
-	ident, ok := s.Elt.(*ast.Ident)
+	ident, ok := s.ArrayType.Elt.(*ast.Ident)
 	if !ok {
 		return nil
 	}
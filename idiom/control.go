@@ -0,0 +1,106 @@
+package idiom
+
+import (
+	"go/token"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/internal/detect"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+// BoolComparison detects comparisons with boolean constants, e.g. `if x == true`.
+func BoolComparison(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "bool-comparison", rules.SeverityInfo, func(node astrav.Node) string {
+		bin, ok := node.(*astrav.BinaryExpr)
+		if !ok || bin.BinaryExpr.Op != token.EQL && bin.BinaryExpr.Op != token.NEQ || bin.ConstValue() != nil {
+			return ""
+		}
+
+		for _, operand := range []astrav.Node{bin.X(), bin.Y()} {
+			if _, ok := operand.(*astrav.Ident); !ok {
+				continue
+			}
+			if val, ok := operand.ConstBool(); ok {
+				if val == (bin.BinaryExpr.Op == token.EQL) {
+					return "omit the comparison with a boolean constant: use the value directly"
+				}
+				return "omit the comparison with a boolean constant: negate the value"
+			}
+		}
+		return ""
+	})
+}
+
+// UnnecessaryElse detects else blocks following an if block that ends with a return, break,
+// continue or goto. The else block can be outdented. The diagnostic is reported on the else block.
+func UnnecessaryElse(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "unnecessary-else", rules.SeverityInfo, func(node astrav.Node) string {
+		ifStmt, ok := node.Parent().(*astrav.IfStmt)
+		if !ok || ifStmt.IfStmt.Else != node.AstNode() || !node.IsNodeType(astrav.NodeTypeBlockStmt) {
+			return ""
+		}
+		// else if chains and variables declared in the init statement require the else
+		if parent, ok := ifStmt.Parent().(*astrav.IfStmt); ok && parent.IfStmt.Else == ifStmt.IfStmt {
+			return ""
+		}
+		if assign, ok := ifStmt.Init().(*astrav.AssignStmt); ok && assign.AssignStmt.Tok == token.DEFINE {
+			return ""
+		}
+
+		stmts := ifStmt.BodyBlock().List()
+		if len(stmts) == 0 {
+			return ""
+		}
+		switch last := stmts[len(stmts)-1].(type) {
+		case *astrav.ReturnStmt:
+			return "if block ends with a return statement: drop the else and outdent its block"
+		case *astrav.BranchStmt:
+			if last.BranchStmt.Tok != token.FALLTHROUGH {
+				return "if block ends with a " + last.BranchStmt.Tok.String() + " statement: drop the else and outdent its block"
+			}
+		}
+		return ""
+	})
+}
+
+// MapRangeLookup detects ranging over a map to find a key, e.g. `for k, v := range m { if k == x {...} }`.
+// A map lookup `v, ok := m[x]` should be used instead.
+func MapRangeLookup(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "map-range-lookup", rules.SeverityWarning, func(node astrav.Node) string {
+		rng, ok := node.(*astrav.RangeStmt)
+		if !ok || rng.Key() == nil || !rng.X().HasValueType(astrav.IsMap) {
+			return ""
+		}
+
+		key := detect.ObjectOf(rng.Key())
+		if key == nil {
+			return ""
+		}
+		for _, stmt := range rng.Body().List() {
+			ifStmt, ok := stmt.(*astrav.IfStmt)
+			if !ok {
+				continue
+			}
+			bin, ok := ifStmt.Cond().(*astrav.BinaryExpr)
+			if !ok || bin.BinaryExpr.Op != token.EQL {
+				continue
+			}
+			if detect.ObjectOf(bin.X()) == key && !usesLoopVar(bin.Y(), rng) || detect.ObjectOf(bin.Y()) == key && !usesLoopVar(bin.X(), rng) {
+				return "ranging over a map to find a key: use a map lookup instead"
+			}
+		}
+		return ""
+	})
+}
+
+// usesLoopVar checks if the expression uses a variable declared within the range statement.
+func usesLoopVar(expr astrav.Node, rng *astrav.RangeStmt) bool {
+	var uses bool
+	expr.Walk(func(node astrav.Node) bool {
+		if obj := detect.ObjectOf(node); obj != nil && detect.Declares(rng, obj) {
+			uses = true
+		}
+		return !uses
+	})
+	return uses
+}
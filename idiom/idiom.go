@@ -0,0 +1,38 @@
+// Package idiom detects common Go anti-patterns. Each detector walks the tree of the given node
+// and returns a diagnostic per finding. The matching node is available as Diagnostic.Node.
+//
+//	diags := idiom.Detect(pkg)
+//	diags = idiom.BoolComparison(pkg.FuncDeclByName("IsValid"))
+package idiom
+
+import (
+	"go/types"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/internal/detect"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+// Detector detects an anti-pattern within the tree of the root node.
+type Detector = detect.Detector
+
+// Detectors are all detectors of the package.
+var Detectors = []Detector{
+	StringConcatInLoop,
+	BuilderMisuse,
+	BoolComparison,
+	LenStringComparison,
+	UnnecessaryElse,
+	SprintfString,
+	RegexpCompileInFunc,
+	MapRangeLookup,
+}
+
+// Detect runs all detectors. The diagnostics are sorted by position.
+func Detect(root astrav.Node) []*rules.Diagnostic {
+	return detect.Run(root, Detectors)
+}
+
+func isString(node astrav.Node) bool {
+	return node != nil && node.HasValueType(astrav.IsBasic(types.String))
+}
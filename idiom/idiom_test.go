@@ -0,0 +1,39 @@
+package idiom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tehsphinx/astrav/astravtest"
+)
+
+func TestDetect(t *testing.T) {
+	pkg := astravtest.LoadPackage(t, "testdata/idioms")
+
+	diags := Detect(pkg)
+	astravtest.Check(t, pkg, diags)
+
+	for i := 1; i < len(diags); i++ {
+		assert.False(t, diags[i].Pos.Line < diags[i-1].Pos.Line, "diagnostics must be sorted")
+	}
+}
+
+func TestDetectors(t *testing.T) {
+	tests := []struct {
+		dir    string
+		detect Detector
+	}{
+		{dir: "testdata/concat", detect: StringConcatInLoop},
+		{dir: "testdata/builder", detect: BuilderMisuse},
+		{dir: "testdata/bools", detect: BoolComparison},
+		{dir: "testdata/lengths", detect: LenStringComparison},
+		{dir: "testdata/elses", detect: UnnecessaryElse},
+		{dir: "testdata/lookups", detect: MapRangeLookup},
+		{dir: "testdata/sprintf", detect: SprintfString},
+		{dir: "testdata/regexps", detect: RegexpCompileInFunc},
+	}
+	for _, tt := range tests {
+		pkg := astravtest.LoadPackage(t, tt.dir)
+		astravtest.Check(t, pkg, tt.detect(pkg))
+	}
+}
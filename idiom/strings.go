@@ -0,0 +1,204 @@
+package idiom
+
+import (
+	"go/token"
+	"go/types"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/internal/detect"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+// StringConcatInLoop detects strings built by concatenation in a loop, e.g. `s += x`
+// for a string s declared outside of the loop.
+func StringConcatInLoop(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "string-concat-in-loop", rules.SeverityWarning, func(node astrav.Node) string {
+		assign, ok := node.(*astrav.AssignStmt)
+		if !ok || len(assign.LHS()) != 1 || len(assign.RHS()) != 1 || !isString(assign.LHS()[0]) {
+			return ""
+		}
+
+		obj := detect.ObjectOf(assign.LHS()[0])
+		switch assign.AssignStmt.Tok {
+		case token.ADD_ASSIGN:
+		case token.ASSIGN:
+			bin, ok := assign.RHS()[0].(*astrav.BinaryExpr)
+			if !ok || bin.BinaryExpr.Op != token.ADD || obj == nil || detect.ObjectOf(bin.X()) != obj {
+				return ""
+			}
+		default:
+			return ""
+		}
+
+		loop := detect.EnclosingLoop(assign)
+		if loop == nil || obj != nil && detect.Declares(loop.Node(), obj) {
+			return ""
+		}
+		return "string concatenation in a loop: use a strings.Builder"
+	})
+}
+
+// BuilderMisuse detects a strings.Builder passed or copied by value, and strings built with
+// fmt.Sprintf or concatenation before being written to a builder.
+func BuilderMisuse(root astrav.Node) []*rules.Diagnostic {
+	isBuilder := astrav.IsNamed("strings", "Builder")
+
+	return detect.Walk(root, "strings-builder-misuse", rules.SeverityWarning, func(node astrav.Node) string {
+		switch n := node.(type) {
+		case *astrav.Field:
+			// returning a fresh builder by value is fine, only parameters copy the caller's builder
+			list, ok := n.Parent().(*astrav.FieldList)
+			if !ok {
+				return ""
+			}
+			funcType, ok := list.Parent().(*astrav.FuncType)
+			if ok && list.FieldList == funcType.FuncType.Params && n.Type().HasValueType(isBuilder) {
+				return "strings.Builder must not be copied: pass a pointer"
+			}
+		case *astrav.AssignStmt:
+			if copiesBuilder(n.RHS(), isBuilder) {
+				return "strings.Builder must not be copied: use a pointer"
+			}
+		case *astrav.ValueSpec:
+			if copiesBuilder(n.Values(), isBuilder) {
+				return "strings.Builder must not be copied: use a pointer"
+			}
+		case *astrav.CallExpr:
+			if !detect.IsFunc(n, "(*strings.Builder).WriteString") || len(n.Args()) != 1 {
+				return ""
+			}
+			arg := n.Args()[0]
+			if call, ok := arg.(*astrav.CallExpr); ok && detect.IsFunc(call, "fmt.Sprintf") {
+				return "use fmt.Fprintf to write formatted strings to a strings.Builder"
+			}
+			if bin, ok := arg.(*astrav.BinaryExpr); ok && bin.BinaryExpr.Op == token.ADD && bin.ConstValue() == nil {
+				return "write the parts to the strings.Builder instead of concatenating them"
+			}
+		}
+		return ""
+	})
+}
+
+func copiesBuilder(values []astrav.Node, isBuilder astrav.TypePredicate) bool {
+	for _, value := range values {
+		if _, ok := value.(*astrav.Ident); ok && value.HasValueType(isBuilder) {
+			return true
+		}
+	}
+	return false
+}
+
+// LenStringComparison detects checks for empty strings via their length, e.g. `len(s) == 0`,
+// `0 < len(s)` or `len(s) >= 1`.
+func LenStringComparison(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "len-string-compare", rules.SeverityInfo, func(node astrav.Node) string {
+		bin, ok := node.(*astrav.BinaryExpr)
+		if !ok {
+			return ""
+		}
+
+		// normalize to `len(s) <op> val`
+		op, lenExpr, valExpr := bin.BinaryExpr.Op, bin.X(), bin.Y()
+		if !isStringLen(lenExpr) {
+			op, lenExpr, valExpr = swapComparison(op), bin.Y(), bin.X()
+		}
+		if !isStringLen(lenExpr) {
+			return ""
+		}
+		val, ok := valExpr.ConstInt()
+		if !ok {
+			return ""
+		}
+
+		switch {
+		case val == 0 && (op == token.EQL || op == token.LEQ), val == 1 && op == token.LSS:
+			return `compare the string with "" instead of checking its length: s == ""`
+		case val == 0 && (op == token.NEQ || op == token.GTR), val == 1 && op == token.GEQ:
+			return `compare the string with "" instead of checking its length: s != ""`
+		}
+		return ""
+	})
+}
+
+func isStringLen(node astrav.Node) bool {
+	call, ok := node.(*astrav.CallExpr)
+	return ok && detect.IsBuiltin(call, "len") && len(call.Args()) == 1 && isString(call.Args()[0])
+}
+
+// swapComparison returns the operator comparing the operands in swapped order.
+func swapComparison(op token.Token) token.Token {
+	switch op {
+	case token.LSS:
+		return token.GTR
+	case token.GTR:
+		return token.LSS
+	case token.LEQ:
+		return token.GEQ
+	case token.GEQ:
+		return token.LEQ
+	}
+	return op
+}
+
+// SprintfString detects fmt.Sprintf calls only formatting a string, e.g. `fmt.Sprintf("%s", s)`.
+func SprintfString(root astrav.Node) []*rules.Diagnostic {
+	return detect.Walk(root, "sprintf-string", rules.SeverityWarning, func(node astrav.Node) string {
+		call, ok := node.(*astrav.CallExpr)
+		if !ok || len(call.Args()) != 2 || !detect.IsFunc(call, "fmt.Sprintf") {
+			return ""
+		}
+
+		format, ok := call.Args()[0].ConstString()
+		if !ok || format != "%s" && format != "%v" {
+			return ""
+		}
+
+		arg := call.Args()[1]
+		if t := arg.ValueType(); t != nil && hasStringMethod(t) {
+			return "fmt.Sprintf is unnecessary: call the String method"
+		}
+		if isString(arg) {
+			return "fmt.Sprintf is unnecessary: use the string directly"
+		}
+		return ""
+	})
+}
+
+func hasStringMethod(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "String")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Signature()
+	return sig.Params().Len() == 0 && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Typ[types.String])
+}
+
+// RegexpCompileInFunc detects regular expressions with constant patterns compiled within functions.
+// They should be compiled once at package level.
+func RegexpCompileInFunc(root astrav.Node) []*rules.Diagnostic {
+	funcs := []string{"regexp.MustCompile", "regexp.Compile", "regexp.MustCompilePOSIX", "regexp.CompilePOSIX"}
+
+	return detect.Walk(root, "regexp-compile-in-func", rules.SeverityWarning, func(node astrav.Node) string {
+		call, ok := node.(*astrav.CallExpr)
+		if !ok || len(call.Args()) != 1 || call.Args()[0].ConstValue() == nil {
+			return ""
+		}
+
+		var match bool
+		for _, name := range funcs {
+			match = match || detect.IsFunc(call, name)
+		}
+		if !match {
+			return ""
+		}
+
+		if detect.EnclosingLoop(call) != nil {
+			return "regular expression compiled in a loop: compile it once at package level"
+		}
+		if call.IsContainedByType(astrav.NodeTypeFuncDecl) || call.IsContainedByType(astrav.NodeTypeFuncLit) {
+			return "regular expression compiled in a function: compile it once at package level"
+		}
+		return ""
+	})
+}
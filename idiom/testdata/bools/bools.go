@@ -0,0 +1,17 @@
+package bools
+
+func bools(ok bool, flags map[string]bool) bool {
+	if ok == true { // want "use the value directly"
+		return true
+	}
+	if true != ok { // want "negate the value"
+		return false
+	}
+	if false == ok { // want "negate the value"
+		return false
+	}
+	if ok != false { // want "use the value directly"
+		return true
+	}
+	return flags["a"] == flags["b"] && true == true
+}
@@ -0,0 +1,44 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+func byValue(b strings.Builder) string { // want "strings.Builder must not be copied: pass a pointer"
+	return b.String()
+}
+
+func byPointer(b *strings.Builder, parts ...string) {
+	for _, part := range parts {
+		b.WriteString(part)
+	}
+}
+
+func fresh(prefix string) strings.Builder {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	return sb
+}
+
+func named() (sb strings.Builder, n int) {
+	return sb, n
+}
+
+func copies() int {
+	var sb strings.Builder
+	copied := sb                   // want "must not be copied: use a pointer"
+	var declared = sb              // want "must not be copied: use a pointer"
+	var typed strings.Builder = sb // want "must not be copied: use a pointer"
+	ptr := &sb
+	var ptrDeclared *strings.Builder = &sb
+	return copied.Len() + declared.Len() + typed.Len() + ptr.Len() + ptrDeclared.Len()
+}
+
+func writes(sb *strings.Builder, name string, n int) {
+	sb.WriteString(fmt.Sprintf("%d", n)) // want "use fmt.Fprintf"
+	sb.WriteString(name + "\n")          // want "write the parts"
+	sb.WriteString("a" + "b")
+	sb.WriteString(fmt.Sprint(n))
+	fmt.Fprintf(sb, "%s: %d", name, n)
+}
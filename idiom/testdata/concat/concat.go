@@ -0,0 +1,38 @@
+package concat
+
+func loops(parts []string, rows [][]string) string {
+	var s string
+	n := 0
+	for _, part := range parts {
+		s += part      // want "string concatenation in a loop"
+		s = s + part   // want "string concatenation in a loop"
+		s = part + "," // reassignment without concatenation of s
+		n += len(part) // not a string
+		line := ""
+		line += part // declared in the loop
+		_, _ = line, n
+	}
+	for i := 0; i < len(rows); i++ {
+		for _, cell := range rows[i] {
+			s += cell // want "string concatenation in a loop"
+		}
+	}
+	return s
+}
+
+func closures(parts []string) []func() string {
+	var s string
+	var fns []func() string
+	for _, part := range parts {
+		fns = append(fns, func() string {
+			s += part // the function literal is not executed by the loop
+			return s
+		})
+		func() {
+			for range parts {
+				s += part // want "string concatenation in a loop"
+			}
+		}()
+	}
+	return fns
+}
@@ -0,0 +1,49 @@
+package elses
+
+func elses(x int) string {
+	if x > 3 {
+		return "big"
+	} else { // want "return statement: drop the else"
+		x++
+	}
+
+	for x < 10 {
+		if x > 5 {
+			break
+		} else { // want "break statement: drop the else"
+			x++
+		}
+		if x > 4 {
+			continue
+		} else { // want "continue statement: drop the else"
+			x += 2
+		}
+	}
+
+	if y := x * 2; y > 10 {
+		return "double"
+	} else {
+		x = y
+	}
+
+	if x == 1 {
+		return "one"
+	} else if x == 2 {
+		return "two"
+	} else {
+		return "many"
+	}
+}
+
+func notEnding(x int) int {
+	if x > 0 {
+	} else {
+		x = -x
+	}
+	if x > 1 {
+		x--
+	} else {
+		x++
+	}
+	return x
+}
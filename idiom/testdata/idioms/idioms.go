@@ -0,0 +1,144 @@
+package idioms
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var validName = regexp.MustCompile(`^[a-z]+$`)
+
+// Name has a String method.
+type Name string
+
+func (n Name) String() string {
+	return string(n)
+}
+
+func concat(parts []string) string {
+	var s string
+	for _, part := range parts {
+		s += part   // want "string concatenation in a loop"
+		s = s + "," // want "string concatenation in a loop"
+
+		var inner string
+		inner += part
+		_ = inner
+	}
+	return s
+}
+
+func deferred(parts []string) []func() string {
+	var s string
+	var fns []func() string
+	for _, part := range parts {
+		fns = append(fns, func() string {
+			s += part
+			return regexp.MustCompile(`e+f`).FindString(s) // want "compiled in a function"
+		})
+	}
+	return fns
+}
+
+func build(parts []string, b strings.Builder) string { // want "strings.Builder must not be copied: pass a pointer"
+	var sb strings.Builder
+	for i, part := range parts {
+		sb.WriteString(fmt.Sprintf("%d: %s", i, part)) // want "use fmt.Fprintf"
+		sb.WriteString(part + "\n")                    // want "write the parts"
+		sb.WriteString("a" + "b")
+	}
+	copied := sb      // want "must not be copied: use a pointer"
+	var declared = sb // want "must not be copied: use a pointer"
+	return sb.String() + b.String() + fmt.Sprint(copied.Len(), declared.Len())
+}
+
+func newBuilder(prefix string) strings.Builder {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	return sb
+}
+
+func compare(ok bool, s string, n Name) string {
+	if ok == true { // want "use the value directly"
+		return fmt.Sprintf("%s", s) // want "use the string directly"
+	}
+	if false == ok { // want "negate the value"
+		return fmt.Sprintf("%v", n) // want "call the String method"
+	}
+	if len(s) == 0 { // want `s == ""`
+		return fmt.Sprintf("%s!", s)
+	}
+	if len(s) > 0 { // want `s != ""`
+		return validName.FindString(s)
+	}
+	return ""
+}
+
+func lengths(s string, parts []string) bool {
+	switch {
+	case 0 == len(s): // want `s == ""`
+		return false
+	case len(s) < 1: // want `s == ""`
+		return false
+	case 0 < len(s): // want `s != ""`
+		return true
+	case len(s) >= 1: // want `s != ""`
+		return true
+	case 1 <= len(s): // want `s != ""`
+		return true
+	case len(s) > 1, 2 > len(s), len(parts) == 0:
+		return true
+	}
+	return false
+}
+
+func branches(x int) string {
+	if x > 3 {
+		return "big"
+	} else { // want "drop the else"
+		x++
+	}
+
+	for {
+		if x > 10 {
+			break
+		} else { // want "break statement: drop the else"
+			x++
+		}
+	}
+
+	if x == 1 {
+		return "one"
+	} else if x == 2 {
+		return "two"
+	} else {
+		return "many"
+	}
+}
+
+func matcher(patterns []string, input string) bool {
+	re := regexp.MustCompile(`a+b`) // want "compiled in a function"
+	for _, pattern := range patterns {
+		if regexp.MustCompile(`c+d`).MatchString(input) { // want "compiled in a loop"
+			return true
+		}
+		if regexp.MustCompile(pattern).MatchString(input) {
+			return true
+		}
+	}
+	return re.MatchString(input)
+}
+
+func lookup(scores map[string]int, name string) int {
+	for key, value := range scores { // want "use a map lookup"
+		if key == name {
+			return value
+		}
+	}
+	for key, value := range scores {
+		if key == fmt.Sprint(value) {
+			return value
+		}
+	}
+	return 0
+}
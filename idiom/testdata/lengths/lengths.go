@@ -0,0 +1,25 @@
+package lengths
+
+const zero = 0
+
+func lengths(s string, parts []string, b []byte) bool {
+	switch {
+	case len(s) == 0: // want `s == ""`
+	case 0 == len(s): // want `s == ""`
+	case len(s) < 1: // want `s == ""`
+	case 1 > len(s): // want `s == ""`
+	case len(s) <= 0: // want `s == ""`
+	case len(s) == zero: // want `s == ""`
+	case len(s) != 0: // want `s != ""`
+	case 0 != len(s): // want `s != ""`
+	case len(s) > 0: // want `s != ""`
+	case 0 < len(s): // want `s != ""`
+	case len(s) >= 1: // want `s != ""`
+	case 1 <= len(s): // want `s != ""`
+	case len(s) > 1, 1 < len(s), len(s) == 1, len(s) < 2:
+	case len(parts) == 0, len(b) > 0, 0 == len(parts):
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+package lookups
+
+func lookups(scores map[string]int, names []string, name string) int {
+	for key, value := range scores { // want "use a map lookup"
+		if key == name {
+			return value
+		}
+	}
+	for key := range scores { // want "use a map lookup"
+		if name == key {
+			return 1
+		}
+	}
+	for key, value := range scores {
+		if key == string(rune(value)) {
+			return value
+		}
+	}
+	for key := range scores {
+		if key != name {
+			return 0
+		}
+	}
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
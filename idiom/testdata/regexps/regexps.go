@@ -0,0 +1,29 @@
+package regexps
+
+import "regexp"
+
+var valid = regexp.MustCompile(`^[a-z]+$`)
+
+var lazy = func() *regexp.Regexp {
+	return regexp.MustCompile(`x+`) // want "compiled in a function"
+}
+
+func match(patterns []string, input string) bool {
+	re := regexp.MustCompile(`a+b`)      // want "compiled in a function"
+	posix, _ := regexp.CompilePOSIX(`c`) // want "compiled in a function"
+	for _, pattern := range patterns {
+		if regexp.MustCompile(`c+d`).MatchString(input) { // want "compiled in a loop"
+			return true
+		}
+		if regexp.MustCompile(pattern).MatchString(input) {
+			return true
+		}
+		check := func() bool {
+			return regexp.MustCompile(`e+f`).MatchString(input) // want "compiled in a function"
+		}
+		if check() {
+			return true
+		}
+	}
+	return re.MatchString(input) || posix.MatchString(input) || valid.MatchString(input) || lazy().MatchString(input)
+}
@@ -0,0 +1,31 @@
+package sprintf
+
+import "fmt"
+
+// Name has a String method.
+type Name string
+
+func (n Name) String() string {
+	return string(n)
+}
+
+// ID has a String method with a pointer receiver.
+type ID int
+
+func (i *ID) String() string {
+	return fmt.Sprint(int(*i))
+}
+
+func formats(s string, n Name, id *ID, i int) []string {
+	return []string{
+		fmt.Sprintf("%s", s),  // want "use the string directly"
+		fmt.Sprintf("%v", s),  // want "use the string directly"
+		fmt.Sprintf("%s", n),  // want "call the String method"
+		fmt.Sprintf("%v", id), // want "call the String method"
+		fmt.Sprintf("%v", i),
+		fmt.Sprintf("%q", s),
+		fmt.Sprintf("%s!", s),
+		fmt.Sprintf("%s%s", s, s),
+		fmt.Sprint(s),
+	}
+}
@@ -0,0 +1,190 @@
+package astrav
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// Import describes an import of a file.
+type Import struct {
+	// Path is the import path.
+	Path string
+	// Alias is the explicit package name of the import. It is empty if the package is not renamed.
+	Alias string
+	// Dot is true for dot imports.
+	Dot bool
+	// Blank is true for imports with the blank identifier.
+	Blank bool
+	// Pkg is the imported package. It is nil without type information.
+	Pkg *types.Package
+	// Spec is the import spec declaring the import.
+	Spec *ImportSpec
+}
+
+// Imports returns the imports of the file in source order.
+func (s *File) Imports() []*Import {
+	var imports []*Import
+	for _, node := range s.FindByNodeType(NodeTypeImportSpec) {
+		spec := node.(*ImportSpec)
+		imp := &Import{
+			Path:  spec.ImportPath(),
+			Alias: spec.NodeName(),
+			Pkg:   spec.ImportedPackage(),
+			Spec:  spec,
+		}
+		imp.Dot = imp.Alias == "."
+		imp.Blank = imp.Alias == "_"
+		imports = append(imports, imp)
+	}
+	return imports
+}
+
+// UnusedImports returns the imports that are not used within the file. Blank imports are never
+// reported. Type checking fails for unused imports, so only packages loaded despite type errors
+// (e.g. via Module) can contain unused imports.
+func (s *File) UnusedImports() []*Import {
+	var unused []*Import
+	for _, imp := range s.Imports() {
+		if !imp.Spec.IsUsed() {
+			unused = append(unused, imp)
+		}
+	}
+	return unused
+}
+
+// ImportPath returns the unquoted import path.
+func (s *ImportSpec) ImportPath() string {
+	path, err := strconv.Unquote(s.ImportSpec.Path.Value)
+	if err != nil {
+		return s.ImportSpec.Path.Value
+	}
+	return path
+}
+
+// PkgName returns the package name object declared by the import. It is nil without type information.
+func (s *ImportSpec) PkgName() *types.PkgName {
+	if s.Info() == nil {
+		return nil
+	}
+
+	var obj types.Object
+	if s.ImportSpec.Name != nil {
+		obj = s.Info().Defs[s.ImportSpec.Name]
+	}
+	if obj == nil {
+		obj = s.Info().Implicits[s.ImportSpec]
+	}
+	pkgName, _ := obj.(*types.PkgName)
+	return pkgName
+}
+
+// ImportedPackage returns the imported package. It is nil without type information.
+func (s *ImportSpec) ImportedPackage() *types.Package {
+	pkgName := s.PkgName()
+	if pkgName == nil {
+		return nil
+	}
+	return pkgName.Imported()
+}
+
+// Usages returns the selector expressions in the file referring to the imported package.
+// Dot and blank imports have no usages via selector expressions.
+func (s *ImportSpec) Usages() []*SelectorExpr {
+	pkgName := s.PkgName()
+	file := s.NextParentByType(NodeTypeFile)
+	if pkgName == nil || file == nil {
+		return nil
+	}
+
+	var usages []*SelectorExpr
+	for _, node := range file.FindByNodeType(NodeTypeSelectorExpr) {
+		sel := node.(*SelectorExpr)
+		if x, ok := sel.SelectorExpr.X.(*ast.Ident); ok && s.Info().Uses[x] == pkgName {
+			usages = append(usages, sel)
+		}
+	}
+	return usages
+}
+
+// IsUsed checks if the imported package is used within the file. Blank imports are always used.
+func (s *ImportSpec) IsUsed() bool {
+	if s.NodeName() == "_" {
+		return true
+	}
+	if s.NodeName() != "." {
+		return len(s.Usages()) != 0
+	}
+
+	imported := s.ImportedPackage()
+	file := s.NextParentByType(NodeTypeFile)
+	if imported == nil || file == nil {
+		return false
+	}
+	return file.TreeNode(func(n Node) bool {
+		ident, ok := n.(*Ident)
+		if !ok {
+			return false
+		}
+		obj := s.Info().Uses[ident.Ident]
+		return obj != nil && obj.Pkg() == imported
+	}) != nil
+}
+
+// UsesPackage checks if any object of the package with given import path is used.
+func (s *baseNode) UsesPackage(path string) bool {
+	return s.findUses(func(obj types.Object) bool {
+		return obj.Pkg() != nil && obj.Pkg().Path() == path
+	}) != nil
+}
+
+// UsesFunction checks if the function or method of the package with given import path is used.
+// Methods are given as "Type.Method", e.g. UsesFunction("strings", "Builder.WriteString").
+func (s *baseNode) UsesFunction(path, name string) bool {
+	recvName, funcName, isMethod := strings.Cut(name, ".")
+	if !isMethod {
+		funcName, recvName = recvName, ""
+	}
+
+	return s.findUses(func(obj types.Object) bool {
+		fn, ok := obj.(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != path || fn.Name() != funcName {
+			return false
+		}
+		return receiverName(fn.Origin()) == recvName
+	}) != nil
+}
+
+func (s *baseNode) findUses(cond func(obj types.Object) bool) Node {
+	if s.Pkg() == nil || s.Info() == nil {
+		return nil
+	}
+
+	uses := s.Info().Uses
+	return s.TreeNode(func(n Node) bool {
+		ident, ok := n.(*Ident)
+		if !ok {
+			return false
+		}
+		obj := uses[ident.Ident]
+		return obj != nil && cond(obj)
+	})
+}
+
+// receiverName returns the name of the receiver type of a method, empty for functions.
+func receiverName(fn *types.Func) string {
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return ""
+	}
+
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return ""
+}
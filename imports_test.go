@@ -0,0 +1,58 @@
+package astrav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_Imports(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/imports")
+	file := pkg.FindFirstByNodeType(NodeTypeFile).(*File)
+
+	imports := file.Imports()
+	if !assert.Equal(t, 4, len(imports)) {
+		return
+	}
+
+	assert.Equal(t, "embed", imports[0].Path)
+	assert.True(t, imports[0].Blank)
+	assert.Equal(t, "fmt", imports[1].Path)
+	assert.Equal(t, "", imports[1].Alias)
+	assert.Equal(t, "fmt", imports[1].Pkg.Name())
+	assert.True(t, imports[2].Dot)
+	assert.Equal(t, "math", imports[2].Pkg.Path())
+	assert.Equal(t, "str", imports[3].Alias)
+	assert.Equal(t, "strings", imports[3].Pkg.Path())
+
+	for _, imp := range imports {
+		assert.True(t, imp.Spec.IsUsed(), imp.Path)
+	}
+	assert.Empty(t, file.UnusedImports())
+
+	var usages []string
+	for _, sel := range imports[3].Spec.Usages() {
+		usages = append(usages, sel.GetSourceString())
+	}
+	assert.Equal(t, []string{"str.Builder", "str.ToUpper"}, usages)
+	assert.Empty(t, imports[2].Spec.Usages())
+}
+
+func TestBaseNode_UsesFunction(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/imports")
+
+	assert.True(t, pkg.UsesPackage("strings"))
+	assert.True(t, pkg.UsesPackage("math"))
+	assert.False(t, pkg.UsesPackage("embed"))
+	assert.False(t, pkg.UsesPackage("os"))
+
+	assert.True(t, pkg.UsesFunction("strings", "ToUpper"))
+	assert.True(t, pkg.UsesFunction("strings", "Builder.WriteString"))
+	assert.True(t, pkg.UsesFunction("strings", "Builder.String"))
+	assert.True(t, pkg.UsesFunction("math", "Sqrt"))
+	assert.False(t, pkg.UsesFunction("strings", "ToLower"))
+	assert.False(t, pkg.UsesFunction("strings", "WriteString"))
+
+	fn := pkg.FindFirstByName("Describe").(*FuncDecl)
+	assert.True(t, fn.Body().UsesFunction("fmt", "Sprint"))
+}
@@ -0,0 +1,63 @@
+// Command astgen generates code of the astrav package from the node types of go/ast:
+// the wrapper types, the NodeType constants, the creator switch and the typed child accessors.
+// It is run with go generate from the root of the repository.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"text/template"
+)
+
+var dir = flag.String("dir", ".", "directory of the astrav package")
+
+type output struct {
+	file string
+	tmpl *template.Template
+}
+
+var outputs = []output{
+	{file: "wraps_gen.go", tmpl: wrapsTmpl},
+	{file: "nodetype_gen.go", tmpl: nodeTypesTmpl},
+	{file: "creator_gen.go", tmpl: creatorTmpl},
+	{file: "accessors_gen.go", tmpl: accessorsTmpl},
+}
+
+func main() {
+	flag.Parse()
+
+	nodes, err := loadNodes()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, out := range outputs {
+		src, err := render(out.tmpl, nodes)
+		if err != nil {
+			log.Fatalf("%s: %v", out.file, err)
+		}
+		if err := write(filepath.Join(*dir, out.file), src); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func render(tmpl *template.Template, nodes []*node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nodes); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func write(file string, src []byte) error {
+	old, err := ioutil.ReadFile(file)
+	if err == nil && bytes.Equal(old, src) {
+		return nil
+	}
+	return ioutil.WriteFile(file, src, 0644)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pkgDir = "../.."
+
+func TestAllNodesWrapped(t *testing.T) {
+	nodes, err := loadNodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := ioutil.ReadFile(filepath.Join(pkgDir, "creator_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range nodes {
+		if !strings.Contains(string(src), "case *ast."+n.Name+":") {
+			t.Errorf("go/ast node type %s is not wrapped by astrav: run go generate", n.Name)
+		}
+	}
+}
+
+func TestGeneratedUpToDate(t *testing.T) {
+	nodes, err := loadNodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, out := range outputs {
+		want, err := render(out.tmpl, nodes)
+		if err != nil {
+			t.Fatalf("%s: %v", out.file, err)
+		}
+		got, err := ioutil.ReadFile(filepath.Join(pkgDir, out.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(want, got) {
+			t.Errorf("%s is out of date: run go generate", out.file)
+		}
+	}
+}
+
+func TestLoadNodes(t *testing.T) {
+	nodes, err := loadNodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byName := map[string]*node{}
+	for _, n := range nodes {
+		byName[n.Name] = n
+	}
+
+	assert.Equal(t, "Comment", nodes[0].Name)
+	assert.Nil(t, byName["Directive"])
+	assert.True(t, byName["Package"].Custom)
+	assert.NotNil(t, byName["IndexListExpr"])
+
+	fields := byName["AssignStmt"].Fields
+	assert.Equal(t, 2, len(fields))
+	assert.Equal(t, &field{Name: "Lhs", Method: "LHS", Slice: true}, fields[0])
+
+	for _, f := range byName["FuncDecl"].Fields {
+		assert.NotEqual(t, "Name", f.Name)
+		if f.Name == "Body" {
+			assert.Equal(t, &field{Name: "Body", Method: "Body", Type: "BlockStmt"}, f)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+)
+
+// node describes a node type of go/ast.
+type node struct {
+	Name   string
+	Fields []*field
+	// Custom marks wrapper types that are written by hand because they need additional fields.
+	Custom bool
+}
+
+// field describes a field of a go/ast node type holding one or more child nodes.
+type field struct {
+	Name   string
+	Method string
+	Slice  bool
+	// Type is the astrav wrapper type of the child. It is empty for interface types like ast.Expr.
+	Type string
+	// TypedMethod is set for accessors that return Node for backwards compatibility. The child
+	// is then available with its wrapper type through TypedMethod.
+	TypedMethod string
+}
+
+// skipFields lists fields that do not get an accessor. The Name identifiers are available
+// via GetIdent. The fields of a FieldList are split by name and available via FieldList.Fields.
+// The remaining fields are not part of the tree built by ast.Walk.
+var skipFields = map[string]bool{
+	"File.Name":       true,
+	"File.Imports":    true,
+	"File.Unresolved": true,
+	"File.Comments":   true,
+	"FuncDecl.Name":   true,
+	"TypeSpec.Name":   true,
+	"ImportSpec.Name": true,
+	"FieldList.List":  true,
+}
+
+// ignoreNodes lists node types that are not wrapped. A Directive is parsed from a comment
+// on demand and never part of the tree.
+var ignoreNodes = map[string]bool{
+	"Directive": true,
+}
+
+// customWrappers lists wrapper types that are written by hand.
+var customWrappers = map[string]bool{
+	"Package": true,
+}
+
+// renames maps fields to accessor names differing from the field name.
+var renames = map[string]string{
+	"AssignStmt.Lhs": "LHS",
+	"AssignStmt.Rhs": "RHS",
+}
+
+// compatAccessors lists accessors that returned Node before they were generated. They keep returning
+// Node and the typed accessor is generated with the given name instead.
+var compatAccessors = map[string]string{
+	"IfStmt.Body": "BodyBlock",
+}
+
+// interfaces are the node interfaces of go/ast. Children of these types are returned as Node.
+var interfaces = map[string]bool{
+	"Node": true,
+	"Expr": true,
+	"Stmt": true,
+	"Decl": true,
+	"Spec": true,
+}
+
+// loadNodes parses the source of go/ast and returns all node types in order of declaration.
+func loadNodes() ([]*node, error) {
+	pkg, err := build.Import("go/ast", "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var (
+		structs []*ast.TypeSpec
+		methods = map[string]map[string]bool{}
+	)
+	// pkg.GoFiles is sorted and excludes test files
+	for _, name := range pkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok || !ts.Name.IsExported() {
+						continue
+					}
+					if _, ok := ts.Type.(*ast.StructType); ok {
+						structs = append(structs, ts)
+					}
+				}
+			case *ast.FuncDecl:
+				recv := receiverName(d)
+				if recv == "" {
+					continue
+				}
+				if methods[recv] == nil {
+					methods[recv] = map[string]bool{}
+				}
+				methods[recv][d.Name.Name] = true
+			}
+		}
+	}
+
+	isNode := func(name string) bool {
+		return methods[name]["Pos"] && methods[name]["End"]
+	}
+
+	var nodes []*node
+	for _, ts := range structs {
+		if !isNode(ts.Name.Name) || ignoreNodes[ts.Name.Name] {
+			continue
+		}
+		n := &node{Name: ts.Name.Name, Custom: customWrappers[ts.Name.Name]}
+		for _, f := range ts.Type.(*ast.StructType).Fields.List {
+			for _, name := range f.Names {
+				if fd := newField(n.Name, name.Name, f.Type, isNode); fd != nil {
+					n.Fields = append(n.Fields, fd)
+				}
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// newField creates a field if the type holds child nodes.
+func newField(nodeName, name string, typ ast.Expr, isNode func(string) bool) *field {
+	key := nodeName + "." + name
+	if skipFields[key] {
+		return nil
+	}
+
+	f := &field{Name: name, Method: name}
+	if rename, ok := renames[key]; ok {
+		f.Method = rename
+	}
+
+	if arr, ok := typ.(*ast.ArrayType); ok && arr.Len == nil {
+		f.Slice = true
+		typ = arr.Elt
+	}
+
+	switch t := typ.(type) {
+	case *ast.Ident:
+		if !interfaces[t.Name] {
+			return nil
+		}
+	case *ast.StarExpr:
+		ident, ok := t.X.(*ast.Ident)
+		if !ok || !isNode(ident.Name) || ignoreNodes[ident.Name] {
+			return nil
+		}
+		f.Type = ident.Name
+		f.TypedMethod = compatAccessors[key]
+	default:
+		return nil
+	}
+	return f
+}
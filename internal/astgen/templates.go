@@ -0,0 +1,106 @@
+package main
+
+import "text/template"
+
+const header = `// Code generated by astgen. DO NOT EDIT.
+
+package astrav
+`
+
+var wrapsTmpl = template.Must(template.New("wraps").Parse(header + `
+import "go/ast"
+{{range .}}{{if not .Custom}}
+// {{.Name}} wraps ast.{{.Name}}
+type {{.Name}} struct {
+	*ast.{{.Name}}
+	baseNode
+}
+{{end}}{{end}}`))
+
+var nodeTypesTmpl = template.Must(template.New("nodetypes").Parse(header + `
+// Nodetype contants
+const (
+{{- range .}}
+	NodeType{{.Name}} NodeType = "*astrav.{{.Name}}"
+{{- end}}
+)
+
+var nodeTypes = map[NodeType]bool{
+{{- range .}}
+	NodeType{{.Name}}: true,
+{{- end}}
+}
+`))
+
+var creatorTmpl = template.Must(template.New("creator").Parse(header + `
+import (
+	"go/ast"
+	"log"
+)
+
+func sw(bNode baseNode) Node {
+	if bNode.node == nil {
+		log.Println("astrav creator: given node cannot be nil")
+		return nil
+	}
+
+	switch n := bNode.node.(type) {
+	{{- range .}}
+	case *ast.{{.Name}}:
+		return &{{.Name}}{ {{- .Name}}: n, baseNode: bNode}
+	{{- end}}
+	default:
+		log.Printf("astrav: not implemented ast.Node type found: %T\n", n)
+	}
+	return nil
+}
+`))
+
+var accessorsTmpl = template.Must(template.New("accessors").Parse(header + `
+{{range $node := .}}{{range .Fields}}
+{{if .Slice}}// {{.Method}} returns the child nodes of ast.{{$node.Name}}.{{.Name}}. The nodes have the same
+// indices as in the ast slice.
+func (s *{{$node.Name}}) {{.Method}}() []{{if .Type}}*{{.Type}}{{else}}Node{{end}} {
+	if len(s.{{$node.Name}}.{{.Name}}) == 0 {
+		return nil
+	}
+	nodes := make([]{{if .Type}}*{{.Type}}{{else}}Node{{end}}, 0, len(s.{{$node.Name}}.{{.Name}}))
+	for _, child := range s.{{$node.Name}}.{{.Name}} {
+		{{- if .Type}}
+		n, _ := s.findChildByAstNode(child).(*{{.Type}})
+		nodes = append(nodes, n)
+		{{- else}}
+		nodes = append(nodes, s.findChildByAstNode(child))
+		{{- end}}
+	}
+	return nodes
+}
+{{else}}{{if .TypedMethod}}// {{.Method}} returns the child node of ast.{{$node.Name}}.{{.Name}}. See {{.TypedMethod}} for the typed node.
+func (s *{{$node.Name}}) {{.Method}}() Node {
+	if s.{{$node.Name}}.{{.Name}} == nil {
+		return nil
+	}
+	return s.findChildByAstNode(s.{{$node.Name}}.{{.Name}})
+}
+
+// {{.TypedMethod}} returns the child node of ast.{{$node.Name}}.{{.Name}}
+func (s *{{$node.Name}}) {{.TypedMethod}}() *{{.Type}} {
+	if s.{{$node.Name}}.{{.Name}} == nil {
+		return nil
+	}
+	n, _ := s.findChildByAstNode(s.{{$node.Name}}.{{.Name}}).(*{{.Type}})
+	return n
+}
+{{else}}// {{.Method}} returns the child node of ast.{{$node.Name}}.{{.Name}}
+func (s *{{$node.Name}}) {{.Method}}() {{if .Type}}*{{.Type}}{{else}}Node{{end}} {
+	if s.{{$node.Name}}.{{.Name}} == nil {
+		return nil
+	}
+	{{- if .Type}}
+	n, _ := s.findChildByAstNode(s.{{$node.Name}}.{{.Name}}).(*{{.Type}})
+	return n
+	{{- else}}
+	return s.findChildByAstNode(s.{{$node.Name}}.{{.Name}})
+	{{- end}}
+}
+{{end}}{{end}}{{end}}{{end}}`))
@@ -0,0 +1,99 @@
+// Package detect contains helpers for packages implementing detectors on top of astrav.
+package detect
+
+import (
+	"go/types"
+
+	"github.com/tehsphinx/astrav"
+	"github.com/tehsphinx/astrav/rules"
+)
+
+// Detector detects issues within the tree of the root node.
+type Detector func(root astrav.Node) []*rules.Diagnostic
+
+// Run runs all detectors. The diagnostics are sorted by position.
+func Run(root astrav.Node, detectors []Detector) []*rules.Diagnostic {
+	var diags []*rules.Diagnostic
+	for _, detector := range detectors {
+		diags = append(diags, detector(root)...)
+	}
+	rules.SortDiagnostics(diags)
+	return diags
+}
+
+// Diagnostic creates a diagnostic for the node.
+func Diagnostic(id string, severity rules.Severity, message string, node astrav.Node) *rules.Diagnostic {
+	return &rules.Diagnostic{
+		Rule:     id,
+		Message:  message,
+		Severity: severity,
+		Pos:      node.Position(),
+		Node:     node,
+	}
+}
+
+// Walk collects a diagnostic for every node of the tree for which the check returns a message.
+func Walk(root astrav.Node, id string, severity rules.Severity, check func(node astrav.Node) string) []*rules.Diagnostic {
+	var diags []*rules.Diagnostic
+	root.Walk(func(node astrav.Node) bool {
+		if msg := check(node); msg != "" {
+			diags = append(diags, Diagnostic(id, severity, msg, node))
+		}
+		return true
+	})
+	return diags
+}
+
+// EnclosingLoop returns the innermost loop containing the node within the same function. Unlike
+// Node.EnclosingLoop it stops at function literals as well: a closure created in a loop is not
+// necessarily executed by it.
+func EnclosingLoop(node astrav.Node) *astrav.Loop {
+	loop := node.EnclosingLoop()
+	if loop == nil {
+		return nil
+	}
+	for parent := node.Parent(); parent != nil && parent != loop.Node(); parent = parent.Parent() {
+		if parent.IsNodeType(astrav.NodeTypeFuncLit) {
+			return nil
+		}
+	}
+	return loop
+}
+
+// EnclosingFunc returns the innermost FuncDecl or FuncLit containing the node.
+func EnclosingFunc(node astrav.Node) astrav.Node {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch parent.(type) {
+		case *astrav.FuncDecl, *astrav.FuncLit:
+			return parent
+		}
+	}
+	return nil
+}
+
+// IsFunc checks if the call calls the function or method, e.g. IsFunc(call, "fmt.Sprintf")
+// or IsFunc(call, "(*strings.Builder).WriteString").
+func IsFunc(call *astrav.CallExpr, fullName string) bool {
+	callee := call.Callee()
+	return callee.Func != nil && callee.Func.FullName() == fullName
+}
+
+// IsBuiltin checks if the call calls the builtin function.
+func IsBuiltin(call *astrav.CallExpr, name string) bool {
+	callee := call.Callee()
+	return callee.Builtin != nil && callee.Builtin.Name() == name
+}
+
+// ObjectOf returns the object of an identifier node, nil for other nodes.
+func ObjectOf(node astrav.Node) types.Object {
+	ident, ok := node.(*astrav.Ident)
+	if !ok || ident.Info() == nil {
+		return nil
+	}
+	return ident.Info().ObjectOf(ident.Ident)
+}
+
+// Declares checks if the object is declared within the node.
+func Declares(node astrav.Node, obj types.Object) bool {
+	return node.Pos() <= obj.Pos() && obj.Pos() < node.End()
+}
@@ -0,0 +1,226 @@
+package astrav
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Element is an element of a composite literal.
+type Element struct {
+	// Key is the key of the element. It is nil for elements without key.
+	Key Node
+	// Value is the value of the element.
+	Value Node
+}
+
+// Elements returns the elements of the composite literal with their keys.
+func (s *CompositeLit) Elements() []Element {
+	var elements []Element
+	for _, elt := range s.CompositeLit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			node := s.findChildByAstNode(kv).(*KeyValueExpr)
+			elements = append(elements, Element{Key: node.Key(), Value: node.Value()})
+			continue
+		}
+		elements = append(elements, Element{Value: s.findChildByAstNode(elt)})
+	}
+	return elements
+}
+
+// Decode evaluates a composite literal containing only constants into the value pointed to by into.
+// Maps, slices, arrays, structs and pointers to them are supported. Nested composite literals
+// with elided types are decoded as well.
+func (s *CompositeLit) Decode(into interface{}) error {
+	rv := reflect.ValueOf(into)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("decode target must be a non-nil pointer")
+	}
+	return decodeNode(s, rv.Elem())
+}
+
+func decodeNode(node Node, rv reflect.Value) error {
+	if paren, ok := node.(*ParenExpr); ok {
+		return decodeNode(paren.X(), rv)
+	}
+	if unary, ok := node.(*UnaryExpr); ok && unary.UnaryExpr.Op == token.AND {
+		if rv.Kind() != reflect.Ptr {
+			return errors.Errorf("%s: cannot decode pointer into %s", node.Position(), rv.Type())
+		}
+		return decodeNode(unary.X(), rv)
+	}
+	if ident, ok := node.(*Ident); ok && ident.Name == "nil" {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+
+	if lit, ok := node.(*CompositeLit); ok {
+		if rv.Kind() == reflect.Ptr {
+			ptr := reflect.New(rv.Type().Elem())
+			if err := lit.decode(ptr.Elem()); err != nil {
+				return err
+			}
+			rv.Set(ptr)
+			return nil
+		}
+		return lit.decode(rv)
+	}
+
+	val := node.ConstValue()
+	if val == nil {
+		return errors.Errorf("%s: %s is not a constant", node.Position(), node.GetSourceString())
+	}
+	return decodeConst(node, val, rv)
+}
+
+func (s *CompositeLit) decode(rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Map:
+		return s.decodeMap(rv)
+	case reflect.Slice, reflect.Array:
+		return s.decodeList(rv)
+	case reflect.Struct:
+		return s.decodeStruct(rv)
+	}
+	return errors.Errorf("%s: cannot decode composite literal into %s", s.Position(), rv.Type())
+}
+
+func (s *CompositeLit) decodeMap(rv reflect.Value) error {
+	m := reflect.MakeMapWithSize(rv.Type(), len(s.CompositeLit.Elts))
+	for _, elt := range s.Elements() {
+		if elt.Key == nil {
+			return errors.Errorf("%s: map element without key", elt.Value.Position())
+		}
+		key := reflect.New(rv.Type().Key()).Elem()
+		if err := decodeNode(elt.Key, key); err != nil {
+			return err
+		}
+		value := reflect.New(rv.Type().Elem()).Elem()
+		if err := decodeNode(elt.Value, value); err != nil {
+			return err
+		}
+		m.SetMapIndex(key, value)
+	}
+	rv.Set(m)
+	return nil
+}
+
+func (s *CompositeLit) decodeList(rv reflect.Value) error {
+	var (
+		elements = s.Elements()
+		indexes  = make([]int, len(elements))
+		index    int
+		length   int
+	)
+	for i, elt := range elements {
+		if elt.Key != nil {
+			key, ok := elt.Key.ConstInt()
+			if !ok || key < 0 {
+				return errors.Errorf("%s: invalid index %s", elt.Key.Position(), elt.Key.GetSourceString())
+			}
+			index = int(key)
+		}
+		indexes[i] = index
+		index++
+		if length < index {
+			length = index
+		}
+	}
+
+	list := rv
+	if rv.Kind() == reflect.Slice {
+		list = reflect.MakeSlice(rv.Type(), length, length)
+	} else if rv.Len() < length {
+		return errors.Errorf("%s: %d elements do not fit into %s", s.Position(), length, rv.Type())
+	}
+
+	for i, elt := range elements {
+		if err := decodeNode(elt.Value, list.Index(indexes[i])); err != nil {
+			return err
+		}
+	}
+	rv.Set(list)
+	return nil
+}
+
+func (s *CompositeLit) decodeStruct(rv reflect.Value) error {
+	for i, elt := range s.Elements() {
+		var field reflect.Value
+		switch key := elt.Key.(type) {
+		case nil:
+			if i < rv.NumField() {
+				field = rv.Field(i)
+			}
+		case *Ident:
+			field = rv.FieldByName(key.Name)
+		}
+		if !field.IsValid() {
+			return errors.Errorf("%s: no field for element in %s", elt.Value.Position(), rv.Type())
+		}
+		if !field.CanSet() {
+			return errors.Errorf("%s: cannot set field in %s", elt.Value.Position(), rv.Type())
+		}
+		if err := decodeNode(elt.Value, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeConst(node Node, val constant.Value, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Bool:
+		if val.Kind() == constant.Bool {
+			rv.SetBool(constant.BoolVal(val))
+			return nil
+		}
+	case reflect.String:
+		if val.Kind() == constant.String {
+			rv.SetString(constant.StringVal(val))
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, ok := node.ConstInt(); ok && !rv.OverflowInt(i) {
+			rv.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u, ok := constant.Uint64Val(constant.ToInt(val)); ok && !rv.OverflowUint(u) {
+			rv.SetUint(u)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, ok := node.ConstFloat(); ok && !rv.OverflowFloat(f) {
+			rv.SetFloat(f)
+			return nil
+		}
+	case reflect.Interface:
+		if v := naturalConst(node, val); rv.NumMethod() == 0 && v != nil {
+			rv.Set(reflect.ValueOf(v))
+			return nil
+		}
+	}
+	return errors.Errorf("%s: cannot decode %s into %s", node.Position(), val.ExactString(), rv.Type())
+}
+
+// naturalConst converts a constant to bool, string, int64 or float64.
+func naturalConst(node Node, val constant.Value) interface{} {
+	switch val.Kind() {
+	case constant.Bool:
+		return constant.BoolVal(val)
+	case constant.String:
+		return constant.StringVal(val)
+	case constant.Int:
+		if i, ok := node.ConstInt(); ok {
+			return i
+		}
+	case constant.Float:
+		if f, ok := node.ConstFloat(); ok {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package astrav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type literalPoint struct {
+	X, Y  int
+	Label string
+	Tags  []string
+}
+
+func literalByName(t *testing.T, pkg Node, name string) *CompositeLit {
+	spec := pkg.FindFirstIdentByName(name).Parent().(*ValueSpec)
+	return spec.Values()[0].(*CompositeLit)
+}
+
+func TestCompositeLit_Elements(t *testing.T) {
+	pkg := getPackage(t, 8)
+
+	lit := pkg.FindFirstByNodeType(NodeTypeCompositeLit).(*CompositeLit)
+	elements := lit.Elements()
+	assert.Equal(t, 7, len(elements))
+	assert.Equal(t, `"Kk"`, elements[4].Key.GetSourceString())
+	assert.Equal(t, "5", elements[4].Value.GetSourceString())
+
+	pkg = getPackageFromPath(t, "testdata/literals")
+
+	elements = literalByName(t, pkg, "weights").Elements()
+	assert.Equal(t, 3, len(elements))
+	assert.Equal(t, "3", elements[1].Key.GetSourceString())
+	assert.Nil(t, elements[2].Key)
+}
+
+func TestCompositeLit_Decode(t *testing.T) {
+	pkg := getPackage(t, 8)
+
+	var scores map[string]int
+	lit := pkg.FindFirstByNodeType(NodeTypeCompositeLit).(*CompositeLit)
+	assert.NoError(t, lit.Decode(&scores))
+	assert.Equal(t, 10, scores["QZqz"])
+	assert.Equal(t, 7, len(scores))
+
+	pkg = getPackageFromPath(t, "testdata/literals")
+
+	var points []*literalPoint
+	assert.NoError(t, literalByName(t, pkg, "points").Decode(&points))
+	assert.Equal(t, []*literalPoint{
+		{X: 1, Y: 4, Label: "a", Tags: []string{"x", "y"}},
+		{X: 3, Y: 4, Label: "b"},
+	}, points)
+
+	var weights [5]float64
+	assert.NoError(t, literalByName(t, pkg, "weights").Decode(&weights))
+	assert.Equal(t, [5]float64{0, 0.5, 0, 1.5, 2.5}, weights)
+
+	var slice []float64
+	assert.NoError(t, literalByName(t, pkg, "weights").Decode(&slice))
+	assert.Equal(t, 5, len(slice))
+
+	var grid map[string][2]bool
+	assert.NoError(t, literalByName(t, pkg, "grid").Decode(&grid))
+	assert.Equal(t, [2]bool{false, true}, grid["second"])
+
+	var generic map[string]interface{}
+	assert.Error(t, literalByName(t, pkg, "grid").Decode(&generic))
+
+	var dynamic []string
+	err := literalByName(t, pkg, "dynamic").Decode(&dynamic)
+	assert.EqualError(t, err, `literals.go:26:24: strings.ToUpper("a") is not a constant`)
+
+	var tooSmall [2]float64
+	assert.Error(t, literalByName(t, pkg, "weights").Decode(&tooSmall))
+	assert.Error(t, literalByName(t, pkg, "weights").Decode(weights))
+}
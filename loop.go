@@ -0,0 +1,360 @@
+package astrav
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// Loop gives access to the analysis of a for or range loop.
+type Loop struct {
+	node Node
+	body *BlockStmt
+}
+
+// Loop returns the loop analysis of the for statement.
+func (s *ForStmt) Loop() *Loop {
+	return &Loop{node: s, body: s.Body()}
+}
+
+// Loop returns the loop analysis of the range statement.
+func (s *RangeStmt) Loop() *Loop {
+	return &Loop{node: s, body: s.Body()}
+}
+
+// EnclosingLoop returns the innermost loop containing the node within the same function
+// declaration. Loops outside of function literals are included. It returns nil if the node
+// is not within a loop.
+func (s *baseNode) EnclosingLoop() *Loop {
+	for parent := s.parent; parent != nil; parent = parent.Parent() {
+		switch p := parent.(type) {
+		case *ForStmt:
+			return p.Loop()
+		case *RangeStmt:
+			return p.Loop()
+		case *FuncDecl:
+			return nil
+		}
+	}
+	return nil
+}
+
+// Node returns the ForStmt or RangeStmt of the loop.
+func (s *Loop) Node() Node {
+	return s.node
+}
+
+// Body returns the body of the loop.
+func (s *Loop) Body() *BlockStmt {
+	return s.body
+}
+
+// Parent returns the loop enclosing this loop. It returns nil for outermost loops.
+func (s *Loop) Parent() *Loop {
+	return s.node.EnclosingLoop()
+}
+
+// Depth returns the nesting depth of the loop. Outermost loops have depth 1.
+func (s *Loop) Depth() int {
+	depth := 1
+	for parent := s.Parent(); parent != nil; parent = parent.Parent() {
+		depth++
+	}
+	return depth
+}
+
+// NestedLoops returns all loops within the body of the loop.
+func (s *Loop) NestedLoops() []*Loop {
+	var loops []*Loop
+	for _, node := range s.body.TreeNodes(isLoop) {
+		loops = append(loops, loopOf(node))
+	}
+	return loops
+}
+
+// NestedLoopsOverSame returns the nested range loops ranging over the same collection as this loop.
+func (s *Loop) NestedLoopsOverSame() []*Loop {
+	var loops []*Loop
+	for _, loop := range s.NestedLoops() {
+		if s.SameCollection(loop) {
+			loops = append(loops, loop)
+		}
+	}
+	return loops
+}
+
+// SameCollection checks if both loops are range loops over the same variable or field.
+func (s *Loop) SameCollection(other *Loop) bool {
+	a, ok := s.node.(*RangeStmt)
+	if !ok {
+		return false
+	}
+	b, ok := other.node.(*RangeStmt)
+	if !ok {
+		return false
+	}
+
+	objA, objB := collectionObject(a), collectionObject(b)
+	return objA != nil && objA == objB
+}
+
+func collectionObject(rng *RangeStmt) types.Object {
+	if rng.Info() == nil {
+		return nil
+	}
+	switch x := ast.Unparen(rng.RangeStmt.X).(type) {
+	case *ast.Ident:
+		return rng.Info().ObjectOf(x)
+	case *ast.SelectorExpr:
+		return rng.Info().ObjectOf(x.Sel)
+	}
+	return nil
+}
+
+// CarriedVars returns the variables declared outside the loop which are written within the loop,
+// carrying values from one iteration to the next. The variables are sorted by position.
+func (s *Loop) CarriedVars() []*types.Var {
+	var vars []*types.Var
+	for obj := range s.writes() {
+		v, ok := obj.(*types.Var)
+		if !ok || v.IsField() || s.declares(v) {
+			continue
+		}
+		vars = append(vars, v)
+	}
+	sort.Slice(vars, func(i, j int) bool {
+		return vars[i].Pos() < vars[j].Pos()
+	})
+	return vars
+}
+
+// IsInvariant checks if the expression within the loop evaluates to the same value in every iteration.
+// Expressions are considered variant if they use variables declared or written within the loop,
+// call functions other than len, cap and conversions, receive from channels or dereference pointers.
+func (s *Loop) IsInvariant(expr Node) bool {
+	return s.isInvariant(expr, s.writes())
+}
+
+func (s *Loop) isInvariant(expr Node, writes map[types.Object]bool) bool {
+	if s.node.Info() == nil {
+		return false
+	}
+
+	info := s.node.Info()
+	invariant := true
+	expr.Walk(func(node Node) bool {
+		switch n := node.(type) {
+		case *Ident:
+			if v, ok := info.Uses[n.Ident].(*types.Var); ok && (writes[v] || s.declares(v)) {
+				invariant = false
+			}
+		case *CallExpr:
+			callee := n.Callee()
+			isLen := callee.Builtin != nil && (callee.Builtin.Name() == "len" || callee.Builtin.Name() == "cap")
+			if !isLen && callee.Kind != CallConversion {
+				invariant = false
+			}
+		case *StarExpr:
+			if !info.Types[n.StarExpr].IsType() {
+				invariant = false
+			}
+		case *UnaryExpr:
+			if n.UnaryExpr.Op == token.ARROW {
+				invariant = false
+			}
+		case *FuncLit:
+			invariant = false
+		}
+		return invariant
+	})
+	return invariant
+}
+
+// InvariantExprs returns the loop invariant expressions within the body of the loop that compute
+// a value, e.g. `len(prefix) * 2`. Constants, identifiers and selectors are not returned. For nested
+// invariant expressions only the outermost is returned.
+func (s *Loop) InvariantExprs() []Node {
+	if s.node.Info() == nil {
+		return nil
+	}
+
+	var (
+		info   = s.node.Info()
+		writes = s.writes()
+		exprs  []Node
+	)
+	s.body.Walk(func(node Node) bool {
+		expr, ok := node.AstNode().(ast.Expr)
+		if !ok || info.Types[expr].IsType() || node.ConstValue() != nil {
+			return true
+		}
+		switch node.(type) {
+		case *BinaryExpr, *CallExpr, *IndexExpr, *SliceExpr:
+		default:
+			return true
+		}
+		if !s.isInvariant(node, writes) {
+			return true
+		}
+		exprs = append(exprs, node)
+		return false
+	})
+	return exprs
+}
+
+// Allocations returns the allocations within the loop: calls of make, new and append as well as
+// composite literals. Nested composite literals are part of their outermost literal.
+func (s *Loop) Allocations() []Node {
+	var allocs []Node
+	s.node.Walk(func(node Node) bool {
+		switch n := node.(type) {
+		case *CallExpr:
+			callee := n.Callee()
+			if callee.Builtin == nil {
+				return true
+			}
+			switch callee.Builtin.Name() {
+			case "make", "new", "append":
+				allocs = append(allocs, n)
+			}
+		case *CompositeLit:
+			allocs = append(allocs, n)
+			return false
+		}
+		return true
+	})
+	return allocs
+}
+
+// Calls returns the function calls within the loop, excluding builtins and conversions.
+func (s *Loop) Calls() []*CallExpr {
+	return filterCalls(s.node.TreeNodes(isCall))
+}
+
+// CallTreeCalls returns the function calls within the loop and within the functions called
+// by the loop, excluding builtins and conversions.
+func (s *Loop) CallTreeCalls() []*CallExpr {
+	return filterCalls(s.node.CallTreeNodes(isCall))
+}
+
+func filterCalls(nodes []Node) []*CallExpr {
+	var calls []*CallExpr
+	for _, node := range nodes {
+		call := node.(*CallExpr)
+		switch call.Callee().Kind {
+		case CallBuiltin, CallConversion:
+			continue
+		}
+		calls = append(calls, call)
+	}
+	return calls
+}
+
+// declares checks if the object is declared within the loop.
+func (s *Loop) declares(obj types.Object) bool {
+	return s.node.Pos() <= obj.Pos() && obj.Pos() < s.node.End()
+}
+
+// writes returns the objects written within the loop.
+func (s *Loop) writes() map[types.Object]bool {
+	return writtenObjects(s.node)
+}
+
+// writtenObjects returns the objects written within the tree of the node. Taking the address of
+// a variable and calling methods with pointer receivers count as writes.
+func writtenObjects(root Node) map[types.Object]bool {
+	writes := map[types.Object]bool{}
+	info := root.Info()
+	if info == nil {
+		return writes
+	}
+
+	mark := func(expr ast.Expr) {
+		if ident := rootIdent(expr); ident != nil {
+			if obj := info.ObjectOf(ident); obj != nil {
+				writes[obj] = true
+			}
+		}
+	}
+	root.Walk(func(node Node) bool {
+		switch n := node.(type) {
+		case *AssignStmt:
+			for _, lhs := range n.AssignStmt.Lhs {
+				mark(lhs)
+			}
+		case *IncDecStmt:
+			mark(n.IncDecStmt.X)
+		case *RangeStmt:
+			if n.RangeStmt.Key != nil {
+				mark(n.RangeStmt.Key)
+			}
+			if n.RangeStmt.Value != nil {
+				mark(n.RangeStmt.Value)
+			}
+		case *UnaryExpr:
+			if n.UnaryExpr.Op == token.AND {
+				mark(n.UnaryExpr.X)
+			}
+		case *CallExpr:
+			sel, ok := ast.Unparen(n.CallExpr.Fun).(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if callee := n.Callee(); callee.Kind == CallMethod && pointerRecv(callee.Func) {
+				mark(sel.X)
+			}
+		}
+		return true
+	})
+	return writes
+}
+
+func pointerRecv(fn *types.Func) bool {
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return false
+	}
+	_, ok := recv.Type().(*types.Pointer)
+	return ok
+}
+
+// rootIdent returns the identifier an expression like a.b[i].c is based on.
+func rootIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return e
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.SliceExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+func isLoop(n Node) bool {
+	return n.IsNodeType(NodeTypeForStmt) || n.IsNodeType(NodeTypeRangeStmt)
+}
+
+func isCall(n Node) bool {
+	return n.IsNodeType(NodeTypeCallExpr)
+}
+
+func loopOf(node Node) *Loop {
+	switch n := node.(type) {
+	case *ForStmt:
+		return n.Loop()
+	case *RangeStmt:
+		return n.Loop()
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package astrav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sources(nodes []Node) []string {
+	var res []string
+	for _, node := range nodes {
+		res = append(res, node.GetSourceString())
+	}
+	return res
+}
+
+func TestLoop(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/loops")
+
+	loops := pkg.FindByNodeType(NodeTypeRangeStmt)
+	outer := loops[0].(*RangeStmt).Loop()
+	inner := loops[1].(*RangeStmt).Loop()
+
+	assert.Equal(t, 1, outer.Depth())
+	assert.Equal(t, 2, inner.Depth())
+	assert.Nil(t, outer.Parent())
+	assert.Equal(t, outer.Node(), inner.Parent().Node())
+	assert.Equal(t, 1, len(outer.NestedLoops()))
+	assert.Equal(t, 1, len(outer.NestedLoopsOverSame()))
+	assert.True(t, outer.SameCollection(inner))
+
+	buf := pkg.FindFirstIdentByName("buf")
+	assert.Equal(t, inner.Node(), buf.EnclosingLoop().Node())
+	assert.Nil(t, pkg.(*Package).FuncDeclByName("check").Body().EnclosingLoop())
+
+	var carried []string
+	for _, v := range outer.CarriedVars() {
+		carried = append(carried, v.Name())
+	}
+	assert.Equal(t, []string{"total", "out", "c"}, carried)
+	carried = nil
+	for _, v := range inner.CarriedVars() {
+		carried = append(carried, v.Name())
+	}
+	assert.Equal(t, []string{"total"}, carried)
+
+	assert.Equal(t, []string{"len(prefix) * 2"}, sources(outer.InvariantExprs()))
+	assert.Equal(t, []string{"append(out, strings.ToUpper(item))", "make([]byte, limit)"}, sources(outer.Allocations()))
+	assert.Equal(t, []string{"strings.ToUpper(item)", "c.inc()"}, callSources(outer.Calls()))
+
+	forLoop := pkg.FindFirstByNodeType(NodeTypeForStmt).(*ForStmt).Loop()
+	assert.Empty(t, forLoop.CarriedVars())
+	assert.Equal(t, []string{`prefix+"x"`}, sources(forLoop.InvariantExprs()))
+	assert.Equal(t, []string{"[]string{items[j]}"}, sources(forLoop.Allocations()))
+	assert.Equal(t, []string{"check(items[j], prefix+\"x\")"}, callSources(forLoop.Calls()))
+	assert.Equal(t, []string{"check(items[j], prefix+\"x\")", "strings.Contains(s, substr)"}, callSources(forLoop.CallTreeCalls()))
+
+	ident := pkg.FindFirstIdentByName("limit")
+	assert.True(t, outer.IsInvariant(ident))
+	assert.False(t, outer.IsInvariant(outer.Body().FindFirstIdentByName("total")))
+}
+
+func callSources(calls []*CallExpr) []string {
+	var res []string
+	for _, call := range calls {
+		res = append(res, call.GetSourceString())
+	}
+	return res
+}
@@ -0,0 +1,129 @@
+package astrav
+
+import (
+	"go/types"
+	"sort"
+)
+
+// Methods returns the method declarations of the type in the same package.
+// Methods with value and pointer receivers are included.
+func (s *TypeSpec) Methods() []*FuncDecl {
+	if s.Pkg() == nil {
+		return nil
+	}
+	return s.Pkg().MethodsByReceiver(s.NodeName())
+}
+
+// NamedType returns the type declared by the type spec. It returns nil without type information.
+func (s *TypeSpec) NamedType() types.Type {
+	if s.Info() == nil {
+		return nil
+	}
+
+	obj, ok := s.Info().Defs[s.TypeSpec.Name].(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	return obj.Type()
+}
+
+// MethodSet returns the method set of the type or of a pointer to the type.
+func (s *TypeSpec) MethodSet(pointer bool) *types.MethodSet {
+	t := s.NamedType()
+	if t == nil {
+		return types.NewMethodSet(types.NewStruct(nil, nil))
+	}
+	if pointer && !types.IsInterface(t) {
+		t = types.NewPointer(t)
+	}
+	return types.NewMethodSet(t)
+}
+
+// Implements checks if the type or a pointer to the type implements the interface.
+func (s *TypeSpec) Implements(iface *types.Interface) bool {
+	t := s.NamedType()
+	if t == nil || iface == nil {
+		return false
+	}
+	return implements(t, iface)
+}
+
+// ImplementedInterfaces returns the non-empty, non-constraint interfaces implemented by the type or a pointer to it.
+// The universe (error), the package itself, its imports and all packages of the module are searched.
+// Interfaces are sorted by their qualified name.
+func (s *TypeSpec) ImplementedInterfaces() []*types.Named {
+	t := s.NamedType()
+	if t == nil {
+		return nil
+	}
+
+	var (
+		res  []*types.Named
+		seen = map[*types.Named]struct{}{}
+	)
+	for _, scope := range s.interfaceScopes() {
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !obj.Exported() && obj.Pkg() != nil && obj.Pkg() != s.typesPkg() {
+				continue
+			}
+			named, ok := types.Unalias(obj.Type()).(*types.Named)
+			if !ok || types.Identical(named, t) || named.TypeParams().Len() != 0 {
+				continue
+			}
+			iface, ok := named.Underlying().(*types.Interface)
+			if !ok || iface.Empty() || !iface.IsMethodSet() || !implements(t, iface) {
+				continue
+			}
+			if _, ok := seen[named]; ok {
+				continue
+			}
+			seen[named] = struct{}{}
+			res = append(res, named)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].String() < res[j].String()
+	})
+	return res
+}
+
+func (s *TypeSpec) typesPkg() *types.Package {
+	if s.Pkg() == nil {
+		return nil
+	}
+	return s.Pkg().typesPkg
+}
+
+func (s *TypeSpec) interfaceScopes() []*types.Scope {
+	scopes := []*types.Scope{types.Universe}
+
+	pkg := s.Pkg()
+	if pkg == nil || pkg.typesPkg == nil {
+		return scopes
+	}
+
+	scopes = append(scopes, pkg.typesPkg.Scope())
+	for _, imp := range pkg.typesPkg.Imports() {
+		scopes = append(scopes, imp.Scope())
+	}
+	if pkg.module != nil {
+		for _, modPkg := range pkg.module.Pkgs {
+			if modPkg.typesPkg != nil {
+				scopes = append(scopes, modPkg.typesPkg.Scope())
+			}
+		}
+	}
+	return scopes
+}
+
+func implements(t types.Type, iface *types.Interface) bool {
+	if types.Implements(t, iface) {
+		return true
+	}
+	if types.IsInterface(t) {
+		return false
+	}
+	return types.Implements(types.NewPointer(t), iface)
+}
@@ -0,0 +1,46 @@
+package astrav
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeSpec_Methods(t *testing.T) {
+	pkg := getPackage(t, 9)
+
+	joiner := pkg.FindFirstByName("Joiner").(*TypeSpec)
+	var names []string
+	for _, method := range joiner.Methods() {
+		names = append(names, method.NodeName())
+	}
+	assert.ElementsMatch(t, []string{"Join", "SetSep"}, names)
+
+	assert.Equal(t, 1, joiner.MethodSet(false).Len())
+	assert.Equal(t, 2, joiner.MethodSet(true).Len())
+	assert.Equal(t, "SetSep", joiner.MethodSet(true).Lookup(nil, "SetSep").Obj().Name())
+}
+
+func TestTypeSpec_Implements(t *testing.T) {
+	pkg := getPackage(t, 7)
+
+	errType := pkg.FindFirstByName("NotEqualLengthError").(*TypeSpec)
+	errIface := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	assert.True(t, errType.Implements(errIface))
+	assert.Equal(t, 0, errType.MethodSet(false).Len())
+
+	ifaces := errType.ImplementedInterfaces()
+	if assert.Equal(t, 1, len(ifaces)) {
+		assert.Equal(t, "error", ifaces[0].String())
+	}
+
+	pkg = getPackage(t, 9)
+
+	joiner := pkg.FindFirstByName("Joiner").(*TypeSpec)
+	ifaces = joiner.ImplementedInterfaces()
+	if assert.Equal(t, 1, len(ifaces)) {
+		assert.Equal(t, "Separator", ifaces[0].Obj().Name())
+	}
+	assert.False(t, joiner.Implements(errIface))
+}
@@ -4,6 +4,7 @@ import (
 	"go/ast"
 	"go/build"
 	"go/token"
+	"go/types"
 	"io"
 	"io/fs"
 	"os"
@@ -32,10 +33,36 @@ func NewModule(dir string) *Module {
 
 // Module represents a Go module or an application.
 type Module struct {
-	dir string
+	dir      string
+	modDirs  []string
+	workFile string
+	program  *ssa.Program
+	// superseded are the packages replaced by UpdateFile. The SSA program still contains their functions.
+	superseded map[*types.Package]bool
+	// calls indexes the call graph for UpdateFile. It is created on the first update.
+	calls *callIndex
+	// exportedDeps is set if the dependencies were loaded from export data. They have no function bodies.
+	exportedDeps bool
+
+	// CacheDir enables a persistent cache if set. Load stores the export data of all dependencies, the files
+	// and imports of the module packages and the call graph there and reuses them as long as the go env, build
+	// tags, go.mod, go.sum, the Go files of the module and of local replace targets are unchanged. A cached
+	// load does not run the go command and only parses and type-checks the packages of the module. Export data
+	// describes the exported API of the dependencies only: the call graph of a cached load contains all calls
+	// made by the module but not the calls within unexported code of dependencies.
+	CacheDir string
+	// Dependencies selects dependency packages that are wrapped as Packages in Deps. Entries are import paths
+	// or patterns ending in "/..." matching all packages below a path. The sources are taken from wherever the
+	// go command resolves the package: the module cache, the vendor folder or a replace directory.
+	// Call tree searches continue into wrapped dependencies if ResolveCallees is set. The cache is not used if
+	// dependencies are selected.
+	Dependencies []string
+	// ResolveCallees enables Package.SetResolveCallees on all packages of the module and wrapped dependencies.
+	ResolveCallees bool
 
 	FSet     *token.FileSet
 	Pkgs     map[string]*Package
+	Deps     map[string]*Package
 	RawFiles map[string]*RawFile
 
 	Packages []*packages.Package
@@ -61,8 +88,20 @@ func (s *Module) Load(filterFns ...func(d fs.DirEntry) bool) error {
 		return err
 	}
 
-	if r := s.loadPackages(s.dir, paths); r != nil {
-		return r
+	var key string
+	if s.useCache() {
+		if key, err = s.cacheKey(fileSources); err != nil {
+			return err
+		}
+	}
+	graph, err := s.loadCached(key)
+	if err != nil {
+		return err
+	}
+	if graph == nil {
+		if r := s.loadPackages(s.dir, paths); r != nil {
+			return r
+		}
 	}
 
 	s.fillRawFiles(fileSources)
@@ -71,62 +110,99 @@ func (s *Module) Load(filterFns ...func(d fs.DirEntry) bool) error {
 		return r
 	}
 
-	s.buildCallGraph()
+	if r := s.processDependencies(); r != nil {
+		return r
+	}
 
-	return nil
+	if graph != nil {
+		s.buildProgram()
+		s.Graph = s.restoreCallGraph(graph)
+		return nil
+	}
+
+	s.buildCallGraph()
+	return s.storeCache(key)
 }
 
 func (s *Module) buildCallGraph() {
+	s.buildProgram()
+	s.Graph = cha.CallGraph(s.program)
+}
+
+// buildProgram creates and builds the SSA program of all loaded packages.
+func (s *Module) buildProgram() {
 	program, pkgs := ssautil.AllPackages(s.Packages, 0)
+	s.program = program
+	s.superseded = map[*types.Package]bool{}
+	s.calls = nil
 	s.SSAPkgs = pkgs
 
 	program.Build()
-
-	s.Graph = cha.CallGraph(program)
 }
 
 func (s *Module) processPackages() error {
 	for _, pack := range s.Packages {
-		if len(pack.CompiledGoFiles) != len(pack.Syntax) {
-			return errors.Errorf("file without Go code? %v", pack.CompiledGoFiles)
+		if r := s.processPackage(pack); r != nil {
+			return r
 		}
+	}
+	return nil
+}
 
-		files := make(map[string]*ast.File, len(pack.Syntax))
-		for i, fileName := range pack.CompiledGoFiles {
-			file := pack.Syntax[i]
-			files[fileName] = file
-		}
+func (s *Module) processPackage(pack *packages.Package) error {
+	pkgNode, err := s.newPackage(pack)
+	if err != nil {
+		return err
+	}
 
-		pkgNode := creator(baseNode{
-			node: &ast.Package{
-				Name:  pack.PkgPath,
-				Files: files,
-			},
-		}).(*Package)
-
-		pkgNode.rawFiles = map[string]*RawFile{}
-		for fileName, file := range s.RawFiles {
-			dir, _ := path.Split(fileName)
-			relativePath := strings.TrimSuffix(strings.TrimPrefix(dir, s.dir), "/")
-			if !strings.HasSuffix(pack.PkgPath, relativePath) {
-				continue
-			}
+	s.Pkgs[pack.PkgPath] = pkgNode
+	return nil
+}
+
+func (s *Module) newPackage(pack *packages.Package) (*Package, error) {
+	if len(pack.CompiledGoFiles) != len(pack.Syntax) {
+		return nil, errors.Errorf("file without Go code? %v", pack.CompiledGoFiles)
+	}
+
+	files := make(map[string]*ast.File, len(pack.Syntax))
+	for i, fileName := range pack.CompiledGoFiles {
+		file := pack.Syntax[i]
+		files[fileName] = file
+	}
 
+	pkgNode := creator(baseNode{
+		node: &ast.Package{
+			Name:  pack.PkgPath,
+			Files: files,
+		},
+	}).(*Package)
+
+	pkgNode.rawFiles = map[string]*RawFile{}
+	for _, fileName := range pack.CompiledGoFiles {
+		if file, ok := s.RawFiles[fileName]; ok {
 			pkgNode.rawFiles[fileName] = file
 		}
-		pkgNode.info = pack.TypesInfo
-		pkgNode.typesPkg = pack.Types
-		pkgNode.pack = pack
-
-		s.Pkgs[pack.PkgPath] = pkgNode
 	}
-	return nil
+	pkgNode.info = pack.TypesInfo
+	pkgNode.typesPkg = pack.Types
+	pkgNode.pack = pack
+	pkgNode.module = s
+	pkgNode.resolveCallees = s.ResolveCallees
+	return pkgNode, nil
 }
 
 func (s *Module) loadPackages(repoRoot string, paths []string) error {
+	env, cleanup, err := s.workspaceEnv()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
 	s.FSet = token.NewFileSet()
+	s.exportedDeps = false
 	packs, err := packages.Load(&packages.Config{
 		Dir: repoRoot,
+		Env: env,
 		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports |
 			packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedDeps,
 		BuildFlags: build.Default.BuildTags,
@@ -177,19 +253,35 @@ func (s *Module) fillRawFiles(fileSources map[string][]byte) {
 	})
 }
 
-// Package returns a package by name
+// Package returns a package by name. Wrapped dependencies are returned as well.
 func (s *Module) Package(name string) *Package {
-	return s.Pkgs[name]
+	if pkg, ok := s.Pkgs[name]; ok {
+		return pkg
+	}
+	return s.Deps[name]
 }
 
-// loadFiles creates a slice of paths which contain Go packages in given baseDir.
+// loadFiles creates a slice of paths which contain Go packages in the directories of the module.
 func (s *Module) loadFiles(filterFns ...func(d fs.DirEntry) bool) ([]string, map[string][]byte, error) {
 	var (
 		fileSRCs = map[string][]byte{}
 		seen     = map[string]struct{}{}
 		paths    []string
 	)
-	root := os.DirFS(s.dir)
+	for _, dir := range s.Dirs() {
+		dirPaths, err := s.loadDirFiles(dir, fileSRCs, seen, filterFns...)
+		if err != nil {
+			return nil, nil, err
+		}
+		paths = append(paths, dirPaths...)
+	}
+	return paths, fileSRCs, nil
+}
+
+func (s *Module) loadDirFiles(dir string, fileSRCs map[string][]byte, seen map[string]struct{},
+	filterFns ...func(d fs.DirEntry) bool) ([]string, error) {
+	var paths []string
+	root := os.DirFS(dir)
 	err := fs.WalkDir(root, ".", func(filePath string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return fs.SkipDir
@@ -204,21 +296,21 @@ func (s *Module) loadFiles(filterFns ...func(d fs.DirEntry) bool) ([]string, map
 		if err != nil {
 			return err
 		}
-		fileSRCs[path.Join(s.dir, filePath)] = bts
+		fileSRCs[path.Join(dir, filePath)] = bts
 
-		pkgPath := strings.TrimSuffix(filePath, d.Name())
+		pkgPath := path.Join(dir, strings.TrimSuffix(filePath, d.Name()))
 		if _, ok := seen[pkgPath]; ok {
 			return nil
 		}
 		seen[pkgPath] = struct{}{}
 
-		paths = append(paths, path.Join(s.dir, pkgPath))
+		paths = append(paths, pkgPath)
 		return nil
 	})
 	if err != nil {
-		return nil, nil, errors.WithMessage(err, "failed to gather go packages")
+		return nil, errors.WithMessage(err, "failed to gather go packages")
 	}
-	return paths, fileSRCs, nil
+	return paths, nil
 }
 
 func readFile(root fs.FS, filePath string) ([]byte, error) {
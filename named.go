@@ -47,7 +47,7 @@ func (s *File) NodeName() string {
 // NodeName returns the name of the node
 func (s *SelectorExpr) NodeName() string {
 	name := getIdentName(s)
-	if sel := s.findChildByAstNode(s.X); sel != nil && sel.IsNodeType(NodeTypeIdent) {
+	if sel := s.findChildByAstNode(s.SelectorExpr.X); sel != nil && sel.IsNodeType(NodeTypeIdent) {
 		return sel.(*Ident).NodeName() + "." + name
 	}
 	return name
@@ -60,11 +60,11 @@ func (s *TypeSpec) NodeName() string {
 
 // NodeName returns the name of the node
 func (s *CallExpr) NodeName() string {
-	if s.Fun == nil {
+	if s.CallExpr.Fun == nil {
 		return ""
 	}
 
-	node := s.findChildByAstNode(s.Fun)
+	node := s.findChildByAstNode(s.CallExpr.Fun)
 	if t, ok := node.(*ArrayType); ok {
 		return t.NodeName()
 	}
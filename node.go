@@ -3,12 +3,12 @@ package astrav
 import (
 	"bytes"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
 	"math"
 	"reflect"
 	"regexp"
-	"strings"
 )
 
 // NewNode creates a new node
@@ -47,18 +47,26 @@ type Node interface {
 	IsNodeType(nodeType NodeType) bool
 	NodeType() NodeType
 	IsValueType(valType string) bool
+	HasValueType(pred TypePredicate) bool
 	ValueType() types.Type
+	ConstValue() constant.Value
+	ConstInt() (int64, bool)
+	ConstFloat() (float64, bool)
+	ConstString() (string, bool)
+	ConstBool() (bool, bool)
 	Object() types.Object
 	Pkg() *Package
 	Info() *types.Info
 	Walk(f func(node Node) bool)
 
 	GetScope() (Node, *types.Scope)
+	EnclosingScope() *Scope
 	FindByPos(pos token.Pos) (Node, bool)
 	Parent() Node
 	Parents() []Node
 	NextParentByType(nodeType NodeType) Node
 	IsContainedByType(nodeType NodeType) bool
+	EnclosingLoop() *Loop
 	Siblings() []Node
 	Children() []Node
 	Contains(node Node) bool
@@ -75,13 +83,18 @@ type Node interface {
 	FindFirstByNodeType(nodeType NodeType) Node
 	FindNodeTypeInCallTree(nodeType NodeType) []Node
 	FindByValueType(valType string) []Node
+	FindByType(pred TypePredicate) []Node
 	FindByToken(t token.Token) []Node
 	FindMaps() []Node
+	FindConstants() []Node
+	FindMagicNumbers() []Node
 	FindDeclarations() []*Ident
 	FindDeclarationsByType(nodeType NodeType) []*Ident
 	FindVarDeclarations() []*Ident
 	FindDeclaration(usage *Ident) *Ident
 	FindUsages(*Ident) []*Ident
+	UsesPackage(path string) bool
+	UsesFunction(path, name string) bool
 
 	ChildNodes(cond func(n Node) bool) []Node
 	ChildNode(cond func(n Node) bool) Node
@@ -93,6 +106,7 @@ type Node interface {
 
 	GetSource() []byte
 	GetSourceString() string
+	Position() token.Position
 
 	setRealMe(node Node)
 	getRawFile(node ast.Node) *RawFile
@@ -342,12 +356,9 @@ func (s *baseNode) FindByToken(t token.Token) []Node {
 	})
 }
 
-// FindMaps find all nodes with given value type
+// FindMaps find all nodes with a map value type
 func (s *baseNode) FindMaps() []Node {
-	return s.TreeNodes(func(n Node) bool {
-		valueType := n.ValueType()
-		return valueType != nil && strings.HasPrefix(valueType.String(), "map")
-	})
+	return s.FindByType(IsMap)
 }
 
 // FindDeclarations finds all declarations
@@ -645,6 +656,15 @@ func (s *baseNode) GetSourceString() string {
 	return string(s.GetSource())
 }
 
+// Position returns the resolved source position of the node. If the node was created without
+// raw file information (e.g. with NewNode) the zero Position is returned.
+func (s *baseNode) Position() token.Position {
+	if s.rawFile == nil || s.node == nil {
+		return token.Position{}
+	}
+	return s.rawFile.Position(s.node.Pos())
+}
+
 // Match matches the source code of current node and content with given regex
 func (s *baseNode) Match(regex regexp.Regexp) bool {
 	return regex.Match(s.rawFile.source)
@@ -0,0 +1,122 @@
+// Code generated by astgen. DO NOT EDIT.
+
+package astrav
+
+// Nodetype contants
+const (
+	NodeTypeComment        NodeType = "*astrav.Comment"
+	NodeTypeCommentGroup   NodeType = "*astrav.CommentGroup"
+	NodeTypeField          NodeType = "*astrav.Field"
+	NodeTypeFieldList      NodeType = "*astrav.FieldList"
+	NodeTypeBadExpr        NodeType = "*astrav.BadExpr"
+	NodeTypeIdent          NodeType = "*astrav.Ident"
+	NodeTypeEllipsis       NodeType = "*astrav.Ellipsis"
+	NodeTypeBasicLit       NodeType = "*astrav.BasicLit"
+	NodeTypeFuncLit        NodeType = "*astrav.FuncLit"
+	NodeTypeCompositeLit   NodeType = "*astrav.CompositeLit"
+	NodeTypeParenExpr      NodeType = "*astrav.ParenExpr"
+	NodeTypeSelectorExpr   NodeType = "*astrav.SelectorExpr"
+	NodeTypeIndexExpr      NodeType = "*astrav.IndexExpr"
+	NodeTypeIndexListExpr  NodeType = "*astrav.IndexListExpr"
+	NodeTypeSliceExpr      NodeType = "*astrav.SliceExpr"
+	NodeTypeTypeAssertExpr NodeType = "*astrav.TypeAssertExpr"
+	NodeTypeCallExpr       NodeType = "*astrav.CallExpr"
+	NodeTypeStarExpr       NodeType = "*astrav.StarExpr"
+	NodeTypeUnaryExpr      NodeType = "*astrav.UnaryExpr"
+	NodeTypeBinaryExpr     NodeType = "*astrav.BinaryExpr"
+	NodeTypeKeyValueExpr   NodeType = "*astrav.KeyValueExpr"
+	NodeTypeArrayType      NodeType = "*astrav.ArrayType"
+	NodeTypeStructType     NodeType = "*astrav.StructType"
+	NodeTypeFuncType       NodeType = "*astrav.FuncType"
+	NodeTypeInterfaceType  NodeType = "*astrav.InterfaceType"
+	NodeTypeMapType        NodeType = "*astrav.MapType"
+	NodeTypeChanType       NodeType = "*astrav.ChanType"
+	NodeTypeBadStmt        NodeType = "*astrav.BadStmt"
+	NodeTypeDeclStmt       NodeType = "*astrav.DeclStmt"
+	NodeTypeEmptyStmt      NodeType = "*astrav.EmptyStmt"
+	NodeTypeLabeledStmt    NodeType = "*astrav.LabeledStmt"
+	NodeTypeExprStmt       NodeType = "*astrav.ExprStmt"
+	NodeTypeSendStmt       NodeType = "*astrav.SendStmt"
+	NodeTypeIncDecStmt     NodeType = "*astrav.IncDecStmt"
+	NodeTypeAssignStmt     NodeType = "*astrav.AssignStmt"
+	NodeTypeGoStmt         NodeType = "*astrav.GoStmt"
+	NodeTypeDeferStmt      NodeType = "*astrav.DeferStmt"
+	NodeTypeReturnStmt     NodeType = "*astrav.ReturnStmt"
+	NodeTypeBranchStmt     NodeType = "*astrav.BranchStmt"
+	NodeTypeBlockStmt      NodeType = "*astrav.BlockStmt"
+	NodeTypeIfStmt         NodeType = "*astrav.IfStmt"
+	NodeTypeCaseClause     NodeType = "*astrav.CaseClause"
+	NodeTypeSwitchStmt     NodeType = "*astrav.SwitchStmt"
+	NodeTypeTypeSwitchStmt NodeType = "*astrav.TypeSwitchStmt"
+	NodeTypeCommClause     NodeType = "*astrav.CommClause"
+	NodeTypeSelectStmt     NodeType = "*astrav.SelectStmt"
+	NodeTypeForStmt        NodeType = "*astrav.ForStmt"
+	NodeTypeRangeStmt      NodeType = "*astrav.RangeStmt"
+	NodeTypeImportSpec     NodeType = "*astrav.ImportSpec"
+	NodeTypeValueSpec      NodeType = "*astrav.ValueSpec"
+	NodeTypeTypeSpec       NodeType = "*astrav.TypeSpec"
+	NodeTypeBadDecl        NodeType = "*astrav.BadDecl"
+	NodeTypeGenDecl        NodeType = "*astrav.GenDecl"
+	NodeTypeFuncDecl       NodeType = "*astrav.FuncDecl"
+	NodeTypeFile           NodeType = "*astrav.File"
+	NodeTypePackage        NodeType = "*astrav.Package"
+)
+
+var nodeTypes = map[NodeType]bool{
+	NodeTypeComment:        true,
+	NodeTypeCommentGroup:   true,
+	NodeTypeField:          true,
+	NodeTypeFieldList:      true,
+	NodeTypeBadExpr:        true,
+	NodeTypeIdent:          true,
+	NodeTypeEllipsis:       true,
+	NodeTypeBasicLit:       true,
+	NodeTypeFuncLit:        true,
+	NodeTypeCompositeLit:   true,
+	NodeTypeParenExpr:      true,
+	NodeTypeSelectorExpr:   true,
+	NodeTypeIndexExpr:      true,
+	NodeTypeIndexListExpr:  true,
+	NodeTypeSliceExpr:      true,
+	NodeTypeTypeAssertExpr: true,
+	NodeTypeCallExpr:       true,
+	NodeTypeStarExpr:       true,
+	NodeTypeUnaryExpr:      true,
+	NodeTypeBinaryExpr:     true,
+	NodeTypeKeyValueExpr:   true,
+	NodeTypeArrayType:      true,
+	NodeTypeStructType:     true,
+	NodeTypeFuncType:       true,
+	NodeTypeInterfaceType:  true,
+	NodeTypeMapType:        true,
+	NodeTypeChanType:       true,
+	NodeTypeBadStmt:        true,
+	NodeTypeDeclStmt:       true,
+	NodeTypeEmptyStmt:      true,
+	NodeTypeLabeledStmt:    true,
+	NodeTypeExprStmt:       true,
+	NodeTypeSendStmt:       true,
+	NodeTypeIncDecStmt:     true,
+	NodeTypeAssignStmt:     true,
+	NodeTypeGoStmt:         true,
+	NodeTypeDeferStmt:      true,
+	NodeTypeReturnStmt:     true,
+	NodeTypeBranchStmt:     true,
+	NodeTypeBlockStmt:      true,
+	NodeTypeIfStmt:         true,
+	NodeTypeCaseClause:     true,
+	NodeTypeSwitchStmt:     true,
+	NodeTypeTypeSwitchStmt: true,
+	NodeTypeCommClause:     true,
+	NodeTypeSelectStmt:     true,
+	NodeTypeForStmt:        true,
+	NodeTypeRangeStmt:      true,
+	NodeTypeImportSpec:     true,
+	NodeTypeValueSpec:      true,
+	NodeTypeTypeSpec:       true,
+	NodeTypeBadDecl:        true,
+	NodeTypeGenDecl:        true,
+	NodeTypeFuncDecl:       true,
+	NodeTypeFile:           true,
+	NodeTypePackage:        true,
+}
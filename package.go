@@ -16,11 +16,16 @@ type Package struct {
 
 	rawFiles map[string]*RawFile
 	defs     map[*Ident]Node
+	methods  map[string][]*FuncDecl
+	objDefs  map[types.Object]*Ident
+	scopes   map[*types.Scope]*Scope
 	info     *types.Info
 	typesPkg *types.Package
 	pack     *packages.Package
+	module   *Module
 
-	filled bool
+	resolveCallees bool
+	filled         bool
 }
 
 // FuncDeclByName returns a func declaration by name
@@ -38,8 +43,14 @@ func (s *Package) FuncDeclByName(name string) *FuncDecl {
 	return nil
 }
 
-// FuncDeclbyCallExpr returns a function declaration from its usage
+// FuncDeclbyCallExpr returns a function declaration from its usage. The function is looked up by name
+// within the package unless SetResolveCallees was enabled.
 func (s *Package) FuncDeclbyCallExpr(node *CallExpr) *FuncDecl {
+	if s.resolveCallees && s.info != nil {
+		decl, _ := node.Callee().Decl.(*FuncDecl)
+		return decl
+	}
+
 	ident := node.GetIdent()
 	if ident == nil {
 		return nil
@@ -48,6 +59,32 @@ func (s *Package) FuncDeclbyCallExpr(node *CallExpr) *FuncDecl {
 	return s.FuncDeclByName(ident.Name)
 }
 
+// SetResolveCallees enables resolving calls with type information via CallExpr.Callee in FuncDeclbyCallExpr.
+// Methods and functions of other packages of the module are then found as well. As the call tree searches
+// (CallTreeNodes, FindNameInCallTree, ...) use FuncDeclbyCallExpr, they then follow these calls too.
+// It has no effect on packages without type information. It is disabled by default.
+func (s *Package) SetResolveCallees(enable bool) {
+	s.resolveCallees = enable
+}
+
+// MethodsByReceiver returns all method declarations with the given receiver type name.
+// Value and pointer receivers are included.
+func (s *Package) MethodsByReceiver(typeName string) []*FuncDecl {
+	s.fill()
+
+	return s.methods[typeName]
+}
+
+// Module returns the module the package was loaded with. It is nil for packages parsed from a folder.
+func (s *Package) Module() *Module {
+	return s.module
+}
+
+// Types returns the type checked package. It is nil without type information.
+func (s *Package) Types() *types.Package {
+	return s.typesPkg
+}
+
 // GetRawFiles returns the raw files from the package.
 func (s *Package) GetRawFiles() map[string][]byte {
 	var files = map[string][]byte{}
@@ -64,6 +101,7 @@ func (s *Package) fill() {
 	s.filled = true
 
 	s.defs = map[*Ident]Node{}
+	s.methods = map[string][]*FuncDecl{}
 
 	s.Walk(func(node Node) bool {
 		if n, ok := node.(*FuncDecl); ok {
@@ -72,10 +110,16 @@ func (s *Package) fill() {
 				return true
 			}
 			s.defs[ident] = n
+
+			if recv := n.ReceiverTypeName(); recv != "" {
+				s.methods[recv] = append(s.methods[recv], n)
+			}
 		}
 
 		return true
 	})
+
+	s.fillObjDefs()
 }
 
 // // Pos returns the position of the package.
@@ -0,0 +1,35 @@
+package rules
+
+import (
+	"fmt"
+	"go/token"
+
+	"github.com/tehsphinx/astrav"
+)
+
+// Severity of a diagnostic
+type Severity string
+
+// Severity levels
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Diagnostic is a finding of a rule.
+type Diagnostic struct {
+	Rule     string         `json:"rule"`
+	Message  string         `json:"message"`
+	Severity Severity       `json:"severity"`
+	Pos      token.Position `json:"pos"`
+	Node     astrav.Node    `json:"-"`
+}
+
+// String formats the diagnostic similar to compiler output.
+func (s *Diagnostic) String() string {
+	if !s.Pos.IsValid() {
+		return fmt.Sprintf("%s: %s (%s)", s.Severity, s.Message, s.Rule)
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", s.Pos, s.Severity, s.Message, s.Rule)
+}
@@ -0,0 +1,194 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tehsphinx/astrav"
+)
+
+// Condition defines when a rule reports a diagnostic.
+type Condition string
+
+// Conditions of a rule
+const (
+	// MustNotExist reports a diagnostic for every matching node. This is the default.
+	MustNotExist Condition = "must-not-exist"
+	// MustExist reports a single diagnostic if no node matches.
+	MustExist Condition = "must-exist"
+)
+
+// Rule is a declarative rule. It matches nodes within the package, a function (Func) or
+// the call tree of a function (CallTreeOf) and reports diagnostics according to its Condition.
+type Rule struct {
+	ID         string    `json:"id" yaml:"id"`
+	Message    string    `json:"message" yaml:"message"`
+	Severity   Severity  `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Condition  Condition `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Func       string    `json:"func,omitempty" yaml:"func,omitempty"`
+	CallTreeOf string    `json:"callTreeOf,omitempty" yaml:"callTreeOf,omitempty"`
+	Match      Match     `json:"match" yaml:"match"`
+}
+
+// Match describes the nodes a rule is looking for. All given fields must match.
+type Match struct {
+	// Node is the node type, e.g. CallExpr.
+	Node string `json:"node,omitempty" yaml:"node,omitempty"`
+	// Name is the node name as returned by astrav.Named, e.g. strings.ToLower.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// ValueType is the value type of an expression, e.g. map[string]int.
+	ValueType string `json:"valueType,omitempty" yaml:"valueType,omitempty"`
+	// Token is the token of the node, e.g. += or break.
+	Token string `json:"token,omitempty" yaml:"token,omitempty"`
+	// Pattern is a regular expression matched against the source of the node.
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// Inside is a node type one of the parents of the node must have, e.g. ForStmt.
+	Inside string `json:"inside,omitempty" yaml:"inside,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+func (s *Rule) init() error {
+	if s.ID == "" {
+		return errors.New("rule id is required")
+	}
+	if s.Message == "" {
+		return errors.New("rule message is required")
+	}
+	if s.Func != "" && s.CallTreeOf != "" {
+		return errors.New("func and callTreeOf cannot be combined")
+	}
+
+	switch s.Condition {
+	case "":
+		s.Condition = MustNotExist
+	case MustNotExist, MustExist:
+	default:
+		return errors.Errorf("unknown condition %q", s.Condition)
+	}
+
+	switch s.Severity {
+	case "":
+		s.Severity = SeverityWarning
+	case SeverityError, SeverityWarning, SeverityInfo:
+	default:
+		return errors.Errorf("unknown severity %q", s.Severity)
+	}
+
+	return s.Match.init()
+}
+
+func (s *Match) init() error {
+	if *s == (Match{}) {
+		return errors.New("match must not be empty")
+	}
+	if s.Node != "" && !nodeType(s.Node).Valid() {
+		return errors.Errorf("unknown node type %q in match.node", s.Node)
+	}
+	if s.Inside != "" && !nodeType(s.Inside).Valid() {
+		return errors.Errorf("unknown node type %q in match.inside", s.Inside)
+	}
+	if s.Pattern == "" {
+		return nil
+	}
+
+	rx, err := regexp.Compile(s.Pattern)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	s.pattern = rx
+	return nil
+}
+
+// Matches checks if the node matches all conditions of the match.
+func (s *Match) Matches(node astrav.Node) bool {
+	if s.Node != "" && !node.IsNodeType(nodeType(s.Node)) {
+		return false
+	}
+	if s.Name != "" {
+		named, ok := node.(astrav.Named)
+		if !ok || named.NodeName() != s.Name {
+			return false
+		}
+	}
+	if s.Token != "" {
+		tok, ok := node.(astrav.Token)
+		if !ok || tok.Token().String() != s.Token {
+			return false
+		}
+	}
+	if s.ValueType != "" && !node.IsValueType(s.ValueType) {
+		return false
+	}
+	if s.Inside != "" && !node.IsContainedByType(nodeType(s.Inside)) {
+		return false
+	}
+	if s.pattern != nil && !s.pattern.Match(node.GetSource()) {
+		return false
+	}
+	return true
+}
+
+func nodeType(name string) astrav.NodeType {
+	return astrav.NodeType("*astrav." + strings.TrimPrefix(name, "*astrav."))
+}
+
+// Check evaluates the rule against the package.
+func (s *Rule) Check(pkg *astrav.Package) []*Diagnostic {
+	var (
+		scope astrav.Node = pkg
+		nodes []astrav.Node
+	)
+
+	switch {
+	case s.Func != "":
+		fn := pkg.FuncDeclByName(s.Func)
+		if fn == nil {
+			return s.missing(pkg)
+		}
+		scope = fn
+		nodes = fn.TreeNodes(s.Match.Matches)
+	case s.CallTreeOf != "":
+		fn := pkg.FuncDeclByName(s.CallTreeOf)
+		if fn == nil {
+			return s.missing(pkg)
+		}
+		scope = fn
+		nodes = fn.CallTreeNodes(s.Match.Matches)
+	default:
+		nodes = pkg.TreeNodes(s.Match.Matches)
+	}
+
+	if s.Condition == MustExist {
+		if len(nodes) != 0 {
+			return nil
+		}
+		return []*Diagnostic{s.diagnostic(scope)}
+	}
+
+	diags := make([]*Diagnostic, 0, len(nodes))
+	for _, node := range nodes {
+		diags = append(diags, s.diagnostic(node))
+	}
+	return diags
+}
+
+// missing handles rules whose function scope does not exist. A must-exist rule cannot be
+// satisfied without its function and reports on package level.
+func (s *Rule) missing(pkg *astrav.Package) []*Diagnostic {
+	if s.Condition != MustExist {
+		return nil
+	}
+	return []*Diagnostic{s.diagnostic(pkg)}
+}
+
+func (s *Rule) diagnostic(node astrav.Node) *Diagnostic {
+	return &Diagnostic{
+		Rule:     s.ID,
+		Message:  s.Message,
+		Severity: s.Severity,
+		Pos:      node.Position(),
+		Node:     node,
+	}
+}
@@ -0,0 +1,104 @@
+// Package rules implements a rule engine for declarative rules. Rules are written in YAML or JSON
+// and evaluated against an astrav Package producing diagnostics.
+//
+// A rule file looks like this:
+//
+//	rules:
+//	  - id: no-sprintf
+//	    message: use string concatenation instead of fmt.Sprintf
+//	    severity: info
+//	    match:
+//	      node: CallExpr
+//	      name: fmt.Sprintf
+//	  - id: distance-checks-length
+//	    message: Distance should compare the length of the strings
+//	    condition: must-exist
+//	    callTreeOf: Distance
+//	    match:
+//	      name: len
+package rules
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/tehsphinx/astrav"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleSet is a set of rules as loaded from a rule file.
+type RuleSet struct {
+	Rules []*Rule `json:"rules" yaml:"rules"`
+}
+
+// Load loads a rule file. The format is chosen by file extension: .json for JSON,
+// .yaml or .yml for YAML.
+func Load(path string) (*RuleSet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	switch filepath.Ext(path) {
+	case ".json":
+		return ParseJSON(data)
+	case ".yaml", ".yml":
+		return ParseYAML(data)
+	}
+	return nil, errors.Errorf("unknown rule file format: %s", path)
+}
+
+// ParseJSON parses a rule set in JSON format.
+func ParseJSON(data []byte) (*RuleSet, error) {
+	var set RuleSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &set, set.init()
+}
+
+// ParseYAML parses a rule set in YAML format.
+func ParseYAML(data []byte) (*RuleSet, error) {
+	var set RuleSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &set, set.init()
+}
+
+func (s *RuleSet) init() error {
+	for i, rule := range s.Rules {
+		if err := rule.init(); err != nil {
+			return errors.WithMessagef(err, "invalid rule #%d (%s)", i+1, rule.ID)
+		}
+	}
+	return nil
+}
+
+// Check evaluates all rules against the package. The diagnostics are sorted by position.
+func (s *RuleSet) Check(pkg *astrav.Package) []*Diagnostic {
+	var diags []*Diagnostic
+	for _, rule := range s.Rules {
+		diags = append(diags, rule.Check(pkg)...)
+	}
+
+	SortDiagnostics(diags)
+	return diags
+}
+
+// SortDiagnostics sorts diagnostics by file, position and rule id.
+func SortDiagnostics(diags []*Diagnostic) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i].Pos, diags[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Offset != b.Offset {
+			return a.Offset < b.Offset
+		}
+		return diags[i].Rule < diags[j].Rule
+	})
+}
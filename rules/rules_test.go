@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tehsphinx/astrav"
+)
+
+func TestLoad_YAML(t *testing.T) {
+	set, err := Load("testdata/hamming.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 3, len(set.Rules))
+	assert.Equal(t, MustExist, set.Rules[0].Condition)
+	assert.Equal(t, SeverityError, set.Rules[0].Severity)
+	assert.Equal(t, MustNotExist, set.Rules[1].Condition)
+	assert.Equal(t, SeverityWarning, set.Rules[1].Severity)
+}
+
+func TestRuleSet_Check(t *testing.T) {
+	pkg := getPackage(t, 7)
+	set, err := Load("testdata/hamming.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := set.Check(pkg)
+	assert.Equal(t, 2, len(diags))
+
+	assert.Equal(t, "no-increment-in-loop", diags[0].Rule)
+	assert.Equal(t, "example.go:45:4: info: counting inside a loop (no-increment-in-loop)", diags[0].String())
+	assert.Equal(t, "no-println", diags[1].Rule)
+	assert.Equal(t, 55, diags[1].Pos.Line)
+	assert.Equal(t, astrav.NodeTypeSelectorExpr, diags[1].Node.NodeType())
+}
+
+func TestRuleSet_CheckMustExist(t *testing.T) {
+	set, err := Load("testdata/hamming.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := set.Check(getPackage(t, 7))
+	assert.Equal(t, 0, len(diags))
+
+	set.Rules[0].CallTreeOf = "Test"
+	diags = set.Check(getPackage(t, 7))
+	assert.Equal(t, 1, len(diags))
+	assert.Equal(t, "example.go:54:1: warning: Distance should return a custom error (uses-error-type)",
+		diags[0].String())
+
+	set.Rules[0].CallTreeOf = "Missing"
+	diags = set.Check(getPackage(t, 7))
+	assert.Equal(t, 1, len(diags))
+	assert.Equal(t, "warning: Distance should return a custom error (uses-error-type)", diags[0].String())
+}
+
+func TestParseYAML_Invalid(t *testing.T) {
+	_, err := ParseYAML([]byte("rules:\n  - id: a\n    message: b\n    condition: maybe\n    match: {name: x}\n"))
+	assert.EqualError(t, err, `invalid rule #1 (a): unknown condition "maybe"`)
+
+	_, err = ParseYAML([]byte("rules:\n  - id: a\n    message: b\n"))
+	assert.EqualError(t, err, "invalid rule #1 (a): match must not be empty")
+
+	_, err = ParseYAML([]byte("rules:\n  - id: a\n    message: b\n    match: {node: CallExp}\n"))
+	assert.EqualError(t, err, `invalid rule #1 (a): unknown node type "CallExp" in match.node`)
+
+	_, err = ParseYAML([]byte("rules:\n  - id: a\n    message: b\n    match: {node: CallExpr, inside: ForStatement}\n"))
+	assert.EqualError(t, err, `invalid rule #1 (a): unknown node type "ForStatement" in match.inside`)
+
+	_, err = ParseJSON([]byte(`{"rules": [{"id": "a", "message": "b", "match": {"pattern": "("}}]}`))
+	assert.Error(t, err)
+}
+
+func getPackage(t *testing.T, example int) *astrav.Package {
+	folder := astrav.NewFolder(http.Dir(fmt.Sprintf("../example/%d", example)), "")
+	pkgs, err := folder.ParseFolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range pkgs {
+		return pkg
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package astrav
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// Scope is a lexical scope of a package together with the node opening it.
+type Scope struct {
+	scope *types.Scope
+	node  Node
+	pkg   *Package
+}
+
+// Types returns the underlying types.Scope.
+func (s *Scope) Types() *types.Scope {
+	return s.scope
+}
+
+// Node returns the node opening the scope: the Package for the package scope, a File for file
+// scopes, a FuncType for function scopes and the statement or clause for block scopes.
+func (s *Scope) Node() Node {
+	return s.node
+}
+
+// Parent returns the enclosing scope. It returns nil for the package scope.
+func (s *Scope) Parent() *Scope {
+	return s.pkg.scopeOf(s.scope.Parent())
+}
+
+// Children returns the scopes nested directly within the scope.
+func (s *Scope) Children() []*Scope {
+	var children []*Scope
+	for i := 0; i < s.scope.NumChildren(); i++ {
+		if child := s.pkg.scopeOf(s.scope.Child(i)); child != nil {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Declarations returns the identifiers declaring the objects of the scope ordered by position.
+// Imports without explicit name have no identifier and are not returned.
+func (s *Scope) Declarations() []*Ident {
+	s.pkg.fill()
+
+	var decls []*Ident
+	for _, name := range s.scope.Names() {
+		if ident, ok := s.pkg.objDefs[s.scope.Lookup(name)]; ok {
+			decls = append(decls, ident)
+		}
+	}
+	sort.Slice(decls, func(i, j int) bool {
+		return decls[i].Pos() < decls[j].Pos()
+	})
+	return decls
+}
+
+// Lookup resolves the name in the scope and its parents. It returns the object and its declaring
+// node as returned by Package.DeclarationOf. The node is nil for objects of the universe or of
+// packages not loaded.
+func (s *Scope) Lookup(name string) (types.Object, Node) {
+	_, obj := s.scope.LookupParent(name, token.NoPos)
+	if obj == nil {
+		return nil, nil
+	}
+	return obj, s.pkg.DeclarationOf(obj)
+}
+
+// EnclosingScope returns the innermost scope containing the node. Nodes opening a scope are
+// contained by the scope enclosing them. It returns nil without type information.
+func (s *baseNode) EnclosingScope() *Scope {
+	pkg := s.Pkg()
+	if pkg == nil || pkg.info == nil {
+		return nil
+	}
+
+	child := s.AstNode()
+	for parent := s.parent; parent != nil; child, parent = parent.AstNode(), parent.Parent() {
+		node := parent.AstNode()
+		// the body of a function shares the scope of the function type. The name and the type of a
+		// function belong to the enclosing scope.
+		switch n := node.(type) {
+		case *ast.FuncDecl:
+			if child == ast.Node(n.Name) || child == ast.Node(n.Type) {
+				continue
+			}
+			node = n.Type
+		case *ast.FuncLit:
+			if child == ast.Node(n.Type) {
+				continue
+			}
+			node = n.Type
+		}
+		if scope, ok := pkg.info.Scopes[node]; ok {
+			return pkg.scopeOf(scope)
+		}
+	}
+	return pkg.PackageScope()
+}
+
+// PackageScope returns the package scope. It returns nil without type information.
+func (s *Package) PackageScope() *Scope {
+	if s.typesPkg == nil {
+		return nil
+	}
+	return s.scopeOf(s.typesPkg.Scope())
+}
+
+// scopeOf returns the scope wrapping the types.Scope. It returns nil for scopes outside of the package.
+func (s *Package) scopeOf(scope *types.Scope) *Scope {
+	if scope == nil || s.info == nil {
+		return nil
+	}
+	if s.scopes == nil {
+		s.scopes = map[*types.Scope]*Scope{}
+		if s.typesPkg != nil {
+			s.scopes[s.typesPkg.Scope()] = &Scope{scope: s.typesPkg.Scope(), node: s, pkg: s}
+		}
+
+		nodes := map[ast.Node]Node{}
+		s.Walk(func(node Node) bool {
+			nodes[node.AstNode()] = node
+			return true
+		})
+		for node, sc := range s.info.Scopes {
+			if n, ok := nodes[node]; ok {
+				s.scopes[sc] = &Scope{scope: sc, node: n, pkg: s}
+			}
+		}
+	}
+	return s.scopes[scope]
+}
+
+// Shadowing is a declaration shadowing a declaration of an outer scope.
+type Shadowing struct {
+	// Ident is the identifier of the shadowing declaration.
+	Ident *Ident
+	// Shadowed is the object being shadowed.
+	Shadowed types.Object
+	// Decl is the declaring node of the shadowed object. It is nil for objects of the universe.
+	Decl Node
+}
+
+// Shadowings returns all declarations shadowing a declaration of an outer scope including the
+// universe, e.g. a variable named len. The declarations are ordered by position.
+func (s *Package) Shadowings() []*Shadowing {
+	if s.info == nil {
+		return nil
+	}
+	s.fill()
+
+	var res []*Shadowing
+	for obj, ident := range s.objDefs {
+		scope := obj.Parent()
+		if obj.Name() == "_" || scope == nil || scope.Parent() == nil {
+			continue
+		}
+		if _, outer := scope.Parent().LookupParent(obj.Name(), obj.Pos()); outer != nil {
+			res = append(res, &Shadowing{
+				Ident:    ident,
+				Shadowed: outer,
+				Decl:     s.DeclarationOf(outer),
+			})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Ident.Pos() < res[j].Ident.Pos()
+	})
+	return res
+}
@@ -0,0 +1,69 @@
+package astrav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseNode_EnclosingScope(t *testing.T) {
+	pkg := getPackage(t, 7).(*Package)
+
+	pkgScope := pkg.PackageScope()
+	assert.Equal(t, pkg, pkgScope.Node())
+	assert.Nil(t, pkgScope.Parent())
+	assert.Equal(t, 1, len(pkgScope.Children()))
+
+	var names []string
+	for _, ident := range pkgScope.Declarations() {
+		names = append(names, ident.Name)
+	}
+	assert.Equal(t, []string{"NotEqualLengthError", "x", "Distance", "Test"}, names)
+
+	diff := pkg.FuncDeclByName("Distance").Body().FindFirstIdentByName("diff")
+	scope := diff.EnclosingScope()
+	assert.Equal(t, NodeTypeFuncType, scope.Node().NodeType())
+	assert.Equal(t, NodeTypeFile, scope.Parent().Node().NodeType())
+	assert.Equal(t, pkgScope, scope.Parent().Parent())
+
+	obj, decl := scope.Lookup("x")
+	assert.Equal(t, "const x untyped int", obj.String())
+	assert.Equal(t, NodeTypeValueSpec, decl.NodeType())
+	assert.Equal(t, 23, decl.Position().Line)
+
+	obj, decl = scope.Lookup("len")
+	assert.Equal(t, "builtin len", obj.String())
+	assert.Nil(t, decl)
+
+	obj, decl = scope.Lookup("Distance")
+	assert.Equal(t, NodeTypeFuncDecl, decl.NodeType())
+	obj, _ = scope.Lookup("unknown")
+	assert.Nil(t, obj)
+
+	nameScope := pkg.FuncDeclByName("Distance").FindFirstIdentByName("Distance").EnclosingScope()
+	assert.Equal(t, NodeTypeFile, nameScope.Node().NodeType())
+	assert.Equal(t, pkgScope, nameScope.Parent())
+	assert.Equal(t, scope, pkg.FuncDeclByName("Distance").Type().Params().EnclosingScope())
+	assert.Equal(t, scope.Parent(), pkg.FuncDeclByName("Distance").Type().EnclosingScope())
+
+	ifScope := pkg.FindFirstByNodeType(NodeTypeRangeStmt).(*RangeStmt).Body().FindFirstIdentByName("diff").EnclosingScope()
+	assert.Equal(t, NodeTypeBlockStmt, ifScope.Node().NodeType())
+	assert.Equal(t, NodeTypeIfStmt, ifScope.Parent().Node().NodeType())
+	assert.Equal(t, NodeTypeRangeStmt, ifScope.Parent().Parent().Parent().Node().NodeType())
+}
+
+func TestPackage_Shadowings(t *testing.T) {
+	pkg := getPackage(t, 7).(*Package)
+
+	shadowings := pkg.Shadowings()
+	if !assert.Equal(t, 2, len(shadowings)) {
+		return
+	}
+
+	assert.Equal(t, "error", shadowings[0].Ident.Name)
+	assert.Equal(t, "type error interface{Error() string}", shadowings[0].Shadowed.String())
+	assert.Nil(t, shadowings[0].Decl)
+
+	assert.Equal(t, "x", shadowings[1].Ident.Name)
+	assert.Equal(t, 13, shadowings[1].Decl.Position().Line)
+}
@@ -0,0 +1,137 @@
+package astrav
+
+import "go/types"
+
+// SelectorKind classifies selector expressions
+type SelectorKind string
+
+// Kinds of selector expressions
+const (
+	// SelectorUnknown is returned without type information.
+	SelectorUnknown SelectorKind = ""
+	// SelectorQualified is a package qualified identifier, e.g. strings.ToUpper.
+	SelectorQualified SelectorKind = "qualified"
+	// SelectorField is a struct field selection, e.g. p.X.
+	SelectorField SelectorKind = "field"
+	// SelectorMethodValue is a method selection on a concrete value, e.g. p.String.
+	SelectorMethodValue SelectorKind = "methodValue"
+	// SelectorMethodExpr is a method expression, e.g. (*T).String.
+	SelectorMethodExpr SelectorKind = "methodExpr"
+	// SelectorInterfaceMethod is a method selection on an interface value, e.g. err.Error.
+	SelectorInterfaceMethod SelectorKind = "interfaceMethod"
+)
+
+// Selection returns the types.Selection of the selector. It is nil for package qualified identifiers
+// and without type information.
+func (s *SelectorExpr) Selection() *types.Selection {
+	if s.Info() == nil {
+		return nil
+	}
+	return s.Info().Selections[s.SelectorExpr]
+}
+
+// Kind returns the kind of the selector expression.
+func (s *SelectorExpr) Kind() SelectorKind {
+	if s.Info() == nil {
+		return SelectorUnknown
+	}
+
+	sel := s.Selection()
+	if sel == nil {
+		if _, ok := s.Info().Uses[s.SelectorExpr.Sel]; ok {
+			return SelectorQualified
+		}
+		return SelectorUnknown
+	}
+
+	switch sel.Kind() {
+	case types.FieldVal:
+		return SelectorField
+	case types.MethodExpr:
+		return SelectorMethodExpr
+	}
+	if types.IsInterface(sel.Recv()) {
+		return SelectorInterfaceMethod
+	}
+	return SelectorMethodValue
+}
+
+// IsPromoted checks if the selected field or method is promoted via an embedded field.
+func (s *SelectorExpr) IsPromoted() bool {
+	sel := s.Selection()
+	return sel != nil && len(sel.Index()) > 1
+}
+
+// Target returns the object the selector refers to and its declaring node if the declaration
+// is part of the loaded package or module.
+func (s *SelectorExpr) Target() (types.Object, Node) {
+	if s.Info() == nil {
+		return nil, nil
+	}
+
+	var obj types.Object
+	if sel := s.Selection(); sel != nil {
+		obj = sel.Obj()
+	} else {
+		obj = s.Info().Uses[s.SelectorExpr.Sel]
+	}
+	if obj == nil {
+		return nil, nil
+	}
+	return obj, s.Pkg().DeclarationOf(obj)
+}
+
+// DeclarationOf returns the node declaring the object. FuncDecl, TypeSpec, Field and ValueSpec nodes
+// are returned for functions, types, fields and variables, otherwise the declaring identifier.
+// Declarations in other packages are found if the package belongs to the same module.
+// Nil is returned if the declaration is not loaded.
+func (s *Package) DeclarationOf(obj types.Object) Node {
+	if obj == nil || obj.Pkg() == nil {
+		return nil
+	}
+	if m, ok := obj.(*types.Func); ok {
+		obj = m.Origin()
+	}
+	if v, ok := obj.(*types.Var); ok {
+		obj = v.Origin()
+	}
+
+	pkg := s
+	if s.typesPkg == nil || obj.Pkg().Path() != s.typesPkg.Path() {
+		if s.module == nil {
+			return nil
+		}
+		pkg = s.module.Package(obj.Pkg().Path())
+		if pkg == nil {
+			return nil
+		}
+	}
+
+	pkg.fill()
+	ident, ok := pkg.objDefs[obj]
+	if !ok {
+		return nil
+	}
+
+	switch parent := ident.Parent().(type) {
+	case *FuncDecl, *TypeSpec, *Field, *ValueSpec:
+		return parent
+	}
+	return ident
+}
+
+func (s *Package) fillObjDefs() {
+	s.objDefs = map[types.Object]*Ident{}
+	if s.info == nil {
+		return
+	}
+
+	s.Walk(func(node Node) bool {
+		if ident, ok := node.(*Ident); ok {
+			if obj := s.info.Defs[ident.Ident]; obj != nil {
+				s.objDefs[obj] = ident
+			}
+		}
+		return true
+	})
+}
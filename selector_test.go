@@ -0,0 +1,83 @@
+package astrav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectorExpr_Kind(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/selectors")
+
+	kinds := map[string]SelectorKind{}
+	promoted := map[string]bool{}
+	for _, node := range pkg.FindFirstByName("use").FindByNodeType(NodeTypeSelectorExpr) {
+		sel := node.(*SelectorExpr)
+		kinds[sel.GetSourceString()] = sel.Kind()
+		promoted[sel.GetSourceString()] = sel.IsPromoted()
+	}
+
+	assert.Equal(t, map[string]SelectorKind{
+		"(*Item).Name":    SelectorMethodExpr,
+		"i.Describe":      SelectorMethodValue,
+		"n.Name":          SelectorInterfaceMethod,
+		"strings.ToUpper": SelectorQualified,
+		"i.Label":         SelectorField,
+		"strconv.Itoa":    SelectorQualified,
+		"i.ID":            SelectorField,
+		"i.Base":          SelectorField,
+		"i.Base.Describe": SelectorMethodValue,
+	}, kinds)
+	assert.Equal(t, map[string]bool{
+		"(*Item).Name":    false,
+		"i.Describe":      true,
+		"n.Name":          false,
+		"strings.ToUpper": false,
+		"i.Label":         false,
+		"strconv.Itoa":    false,
+		"i.ID":            true,
+		"i.Base":          false,
+		"i.Base.Describe": false,
+	}, promoted)
+}
+
+func TestSelectorExpr_Target(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/selectors")
+
+	targets := map[string]string{}
+	types := map[string]NodeType{}
+	for _, node := range pkg.FindFirstByName("use").FindByNodeType(NodeTypeSelectorExpr) {
+		sel := node.(*SelectorExpr)
+		obj, decl := sel.Target()
+		if !assert.NotNil(t, obj) {
+			continue
+		}
+		if decl == nil {
+			targets[sel.GetSourceString()] = obj.Pkg().Path() + "." + obj.Name()
+			continue
+		}
+		targets[sel.GetSourceString()] = decl.Position().String()
+		types[sel.GetSourceString()] = decl.NodeType()
+	}
+
+	assert.Equal(t, map[string]string{
+		"(*Item).Name":    "selectors.go:30:1",
+		"i.Describe":      "selectors.go:19:1",
+		"n.Name":          "selectors.go:10:2",
+		"strings.ToUpper": "strings.ToUpper",
+		"i.Label":         "selectors.go:26:2",
+		"strconv.Itoa":    "strconv.Itoa",
+		"i.ID":            "selectors.go:15:2",
+		"i.Base":          "selectors.go:25:2",
+		"i.Base.Describe": "selectors.go:19:1",
+	}, targets)
+	assert.Equal(t, map[string]NodeType{
+		"(*Item).Name":    NodeTypeFuncDecl,
+		"i.Describe":      NodeTypeFuncDecl,
+		"n.Name":          NodeTypeField,
+		"i.Label":         NodeTypeField,
+		"i.ID":            NodeTypeField,
+		"i.Base":          NodeTypeField,
+		"i.Base.Describe": NodeTypeFuncDecl,
+	}, types)
+}
@@ -0,0 +1,242 @@
+package astrav
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// Signature gives access to the signature of a function declaration or literal.
+// It is based on types.Signature and requires type information.
+type Signature struct {
+	sig *types.Signature
+	pkg *types.Package
+
+	// Recv is the receiver of a method, nil for functions.
+	Recv *Param
+	// Params are the parameters of the function. The last parameter of a variadic function
+	// has a slice type.
+	Params []*Param
+	// Results are the results of the function.
+	Results []*Param
+	// Variadic is true if the last parameter is variadic.
+	Variadic bool
+}
+
+// Param is a receiver, parameter or result of a signature.
+type Param struct {
+	// Name is the name of the parameter. It is empty for unnamed parameters.
+	Name string
+	// Type is the type of the parameter.
+	Type types.Type
+	// Pointer is true if the type is a pointer.
+	Pointer bool
+	// Var is the types.Var of the parameter.
+	Var *types.Var
+}
+
+func newSignature(sig *types.Signature, pkg *types.Package) *Signature {
+	if sig == nil {
+		return nil
+	}
+
+	s := &Signature{
+		sig:      sig,
+		pkg:      pkg,
+		Params:   newParams(sig.Params()),
+		Results:  newParams(sig.Results()),
+		Variadic: sig.Variadic(),
+	}
+	if recv := sig.Recv(); recv != nil {
+		s.Recv = newParam(recv)
+	}
+	return s
+}
+
+func newParams(tuple *types.Tuple) []*Param {
+	params := make([]*Param, 0, tuple.Len())
+	for i := 0; i < tuple.Len(); i++ {
+		params = append(params, newParam(tuple.At(i)))
+	}
+	return params
+}
+
+func newParam(v *types.Var) *Param {
+	_, isPtr := v.Type().(*types.Pointer)
+	return &Param{
+		Name:    v.Name(),
+		Type:    v.Type(),
+		Pointer: isPtr,
+		Var:     v,
+	}
+}
+
+// Types returns the underlying types.Signature.
+func (s *Signature) Types() *types.Signature {
+	return s.sig
+}
+
+// String returns the signature in Go syntax. Types of the own package are not qualified.
+func (s *Signature) String() string {
+	return types.TypeString(s.sig, s.qualifier())
+}
+
+// IsMethod checks if the signature has a receiver.
+func (s *Signature) IsMethod() bool {
+	return s.Recv != nil
+}
+
+// PointerReceiver checks if the signature has a pointer receiver.
+func (s *Signature) PointerReceiver() bool {
+	return s.Recv != nil && s.Recv.Pointer
+}
+
+// ReceiverTypeName returns the name of the receiver type without pointer, empty for functions.
+func (s *Signature) ReceiverTypeName() string {
+	if s.Recv == nil {
+		return ""
+	}
+
+	t := s.Recv.Type
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return types.TypeString(t, s.qualifier())
+}
+
+// ParamTypes returns the types of the parameters as strings. Types of the own package
+// are not qualified. A variadic parameter is returned as slice type, e.g. []string.
+func (s *Signature) ParamTypes() []string {
+	return s.typeStrings(s.Params)
+}
+
+// ResultTypes returns the types of the results as strings. Types of the own package
+// are not qualified.
+func (s *Signature) ResultTypes() []string {
+	return s.typeStrings(s.Results)
+}
+
+// HasParams checks if the parameter types equal the given type strings, e.g. HasParams("string", "string").
+func (s *Signature) HasParams(typeStrings ...string) bool {
+	return equalStrings(s.ParamTypes(), typeStrings)
+}
+
+// HasResults checks if the result types equal the given type strings, e.g. HasResults("int", "error").
+func (s *Signature) HasResults(typeStrings ...string) bool {
+	return equalStrings(s.ResultTypes(), typeStrings)
+}
+
+// HasNamedResults checks if the results are named.
+func (s *Signature) HasNamedResults() bool {
+	return len(s.Results) != 0 && s.Results[0].Name != ""
+}
+
+// ReturnsErrorLast checks if the last result is of type error.
+func (s *Signature) ReturnsErrorLast() bool {
+	if len(s.Results) == 0 {
+		return false
+	}
+	return IsNamed("", "error")(s.Results[len(s.Results)-1].Type)
+}
+
+func (s *Signature) typeStrings(params []*Param) []string {
+	res := make([]string, 0, len(params))
+	for _, param := range params {
+		res = append(res, types.TypeString(param.Type, s.qualifier()))
+	}
+	return res
+}
+
+func (s *Signature) qualifier() types.Qualifier {
+	return types.RelativeTo(s.pkg)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Signature returns the signature of the function. It returns nil without type information.
+func (s *FuncDecl) Signature() *Signature {
+	if s.Pkg() == nil || s.Info() == nil {
+		return nil
+	}
+
+	fn, ok := s.Info().Defs[s.FuncDecl.Name].(*types.Func)
+	if !ok {
+		return nil
+	}
+	return newSignature(fn.Type().(*types.Signature), fn.Pkg())
+}
+
+// IsMethod checks if the function declaration has a receiver.
+func (s *FuncDecl) IsMethod() bool {
+	return s.FuncDecl.Recv != nil && len(s.FuncDecl.Recv.List) != 0
+}
+
+// IsExported checks if the function or method is exported.
+func (s *FuncDecl) IsExported() bool {
+	return s.FuncDecl.Name.IsExported()
+}
+
+// ReceiverTypeName returns the name of the receiver type without pointer and type parameters.
+// It is empty for functions.
+func (s *FuncDecl) ReceiverTypeName() string {
+	if !s.IsMethod() {
+		return ""
+	}
+
+	expr := s.FuncDecl.Recv.List[0].Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// PointerReceiver checks if the method has a pointer receiver.
+func (s *FuncDecl) PointerReceiver() bool {
+	if !s.IsMethod() {
+		return false
+	}
+
+	expr := s.FuncDecl.Recv.List[0].Type
+	if paren, ok := expr.(*ast.ParenExpr); ok {
+		expr = paren.X
+	}
+	_, ok := expr.(*ast.StarExpr)
+	return ok
+}
+
+// Signature returns the signature of the function literal. It returns nil without type information.
+func (s *FuncLit) Signature() *Signature {
+	if s.Pkg() == nil || s.Info() == nil {
+		return nil
+	}
+
+	sig, ok := s.Info().TypeOf(s.FuncLit).(*types.Signature)
+	if !ok {
+		return nil
+	}
+	return newSignature(sig, s.Pkg().typesPkg)
+}
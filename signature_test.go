@@ -0,0 +1,58 @@
+package astrav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncDecl_Signature(t *testing.T) {
+	pkg := getPackage(t, 7).(*Package)
+
+	distance := pkg.FuncDeclByName("Distance")
+	sig := distance.Signature()
+	assert.False(t, sig.IsMethod())
+	assert.True(t, distance.IsExported())
+	assert.True(t, sig.HasParams("string", "string"))
+	assert.True(t, sig.HasResults("int", "error"))
+	assert.True(t, sig.ReturnsErrorLast())
+	assert.False(t, sig.HasNamedResults())
+	assert.Equal(t, "func(a string, b string) (int, error)", sig.String())
+
+	pkg = getPackage(t, 9).(*Package)
+
+	parse := pkg.FuncDeclByName("Parse")
+	sig = parse.Signature()
+	assert.True(t, sig.HasNamedResults())
+	assert.True(t, sig.ReturnsErrorLast())
+	assert.Equal(t, []string{"[]int", "string", "error"}, sig.ResultTypes())
+	assert.Equal(t, "numbers", sig.Results[0].Name)
+
+	join := pkg.FuncDeclByName("Join")
+	sig = join.Signature()
+	assert.True(t, join.IsMethod())
+	assert.False(t, join.PointerReceiver())
+	assert.Equal(t, "Joiner", join.ReceiverTypeName())
+	assert.True(t, sig.IsMethod())
+	assert.True(t, sig.Variadic)
+	assert.Equal(t, "Joiner", sig.ReceiverTypeName())
+	assert.True(t, sig.HasParams("[]string"))
+	assert.False(t, sig.ReturnsErrorLast())
+
+	setSep := pkg.FuncDeclByName("SetSep")
+	assert.True(t, setSep.PointerReceiver())
+	assert.Equal(t, "Joiner", setSep.ReceiverTypeName())
+	assert.True(t, setSep.Signature().PointerReceiver())
+	assert.Equal(t, "Joiner", setSep.Signature().ReceiverTypeName())
+}
+
+func TestFuncLit_Signature(t *testing.T) {
+	pkg := getPackage(t, 9)
+
+	lit := pkg.FindFirstByNodeType(NodeTypeFuncLit).(*FuncLit)
+	sig := lit.Signature()
+	assert.False(t, sig.IsMethod())
+	assert.True(t, sig.HasParams("string"))
+	assert.True(t, sig.HasResults("int", "error"))
+	assert.Equal(t, "value", sig.Params[0].Name)
+}
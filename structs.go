@@ -0,0 +1,456 @@
+package astrav
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StructType returns the struct type of the type spec. It returns nil if the type is not a struct.
+func (s *TypeSpec) StructType() *StructType {
+	st, _ := s.findChildByAstNode(s.TypeSpec.Type).(*StructType)
+	return st
+}
+
+// StructFields returns the fields of the struct declared by the type spec. Fields declaring
+// multiple names are split into one field per name.
+func (s *TypeSpec) StructFields() []*Field {
+	st := s.StructType()
+	if st == nil {
+		return nil
+	}
+	return st.StructFields()
+}
+
+// StructFields returns the fields of the struct. Fields declaring multiple names are split
+// into one field per name.
+func (s *StructType) StructFields() []*Field {
+	fields := s.Fields()
+	if fields == nil {
+		return nil
+	}
+	return fields.Fields()
+}
+
+// EmbeddedFields returns the embedded fields of the struct.
+func (s *StructType) EmbeddedFields() []*Field {
+	var res []*Field
+	for _, field := range s.StructFields() {
+		if field.IsEmbedded() {
+			res = append(res, field)
+		}
+	}
+	return res
+}
+
+// FieldByName returns the field with given name. Embedded fields are named by their type name.
+func (s *StructType) FieldByName(name string) *Field {
+	for _, field := range s.StructFields() {
+		if field.Name() == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// IsEmbedded checks if the field is an embedded field.
+func (s *Field) IsEmbedded() bool {
+	return len(s.Field.Names) == 0
+}
+
+// Name returns the name of the field. The type name is returned for embedded fields.
+func (s *Field) Name() string {
+	ident := s.nameIdent()
+	if ident == nil {
+		return ""
+	}
+	return ident.Name
+}
+
+// Var returns the variable the field declares. It returns nil without type information.
+func (s *Field) Var() *types.Var {
+	ident := s.nameIdent()
+	if ident == nil || s.Info() == nil {
+		return nil
+	}
+	v, _ := s.Info().Defs[ident].(*types.Var)
+	return v
+}
+
+func (s *Field) nameIdent() *ast.Ident {
+	if len(s.Field.Names) != 0 {
+		return s.Field.Names[0]
+	}
+
+	expr := s.Field.Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.SelectorExpr:
+			return t.Sel
+		case *ast.Ident:
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+// StructTag returns the unquoted struct tag of the field.
+func (s *Field) StructTag() reflect.StructTag {
+	if s.Field.Tag == nil {
+		return ""
+	}
+	tag, err := strconv.Unquote(s.Field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(tag)
+}
+
+// LookupTag returns the value of given key in the struct tag and whether the key was present.
+func (s *Field) LookupTag(key string) (string, bool) {
+	return s.StructTag().Lookup(key)
+}
+
+// TagName returns the name part of the tag value of given key, e.g. "name" for `json:"name,omitempty"`.
+func (s *Field) TagName(key string) string {
+	value, _ := s.LookupTag(key)
+	name, _, _ := strings.Cut(value, ",")
+	return name
+}
+
+// TagError describes an invalid struct tag.
+type TagError struct {
+	Field *Field
+	Key   string
+	Msg   string
+}
+
+// Error implements the error interface.
+func (s *TagError) Error() string {
+	if s.Key == "" {
+		return "field " + s.Field.Name() + ": " + s.Msg
+	}
+	return "field " + s.Field.Name() + ": tag " + s.Key + ": " + s.Msg
+}
+
+var tagOptions = map[string]map[string]bool{
+	"json": {"omitempty": true, "omitzero": true, "string": true},
+	"yaml": {"omitempty": true, "flow": true, "inline": true},
+	"db":   {},
+}
+
+// ValidateTag checks the syntax of the struct tag. Values of the json, yaml and db keys
+// are validated as well.
+func (s *Field) ValidateTag() []*TagError {
+	pairs, err := parseTag(string(s.StructTag()))
+	if err != "" {
+		return []*TagError{{Field: s, Msg: err}}
+	}
+
+	var (
+		res  []*TagError
+		seen = map[string]bool{}
+	)
+	for _, pair := range pairs {
+		if seen[pair.key] {
+			res = append(res, &TagError{Field: s, Key: pair.key, Msg: "duplicate key"})
+			continue
+		}
+		seen[pair.key] = true
+
+		options, ok := tagOptions[pair.key]
+		if !ok {
+			continue
+		}
+		if msg := validateTagValue(pair.key, pair.value, options); msg != "" {
+			res = append(res, &TagError{Field: s, Key: pair.key, Msg: msg})
+		}
+	}
+	return res
+}
+
+// ValidateTags validates the tags of all fields. Besides the checks of Field.ValidateTag
+// it reports names of the json, yaml and db keys used by multiple fields.
+func (s *StructType) ValidateTags() []*TagError {
+	keys := make([]string, 0, len(tagOptions))
+	for key := range tagOptions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var (
+		res   []*TagError
+		names = map[string]map[string]bool{}
+	)
+	for _, field := range s.StructFields() {
+		errs := field.ValidateTag()
+		res = append(res, errs...)
+		if len(errs) != 0 {
+			continue
+		}
+
+		for _, key := range keys {
+			name := field.TagName(key)
+			if name == "" || name == "-" {
+				continue
+			}
+			if names[key] == nil {
+				names[key] = map[string]bool{}
+			}
+			if names[key][name] {
+				res = append(res, &TagError{Field: field, Key: key, Msg: "duplicate name " + strconv.Quote(name)})
+			}
+			names[key][name] = true
+		}
+	}
+	return res
+}
+
+type tagPair struct {
+	key   string
+	value string
+}
+
+// parseTag parses a struct tag according to the conventions of reflect.StructTag.
+func parseTag(tag string) ([]tagPair, string) {
+	var pairs []tagPair
+	for {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			return pairs, ""
+		}
+
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return nil, "bad syntax for struct tag pair"
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return nil, "bad syntax for struct tag value"
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			return nil, "bad syntax for struct tag value"
+		}
+		tag = tag[i+1:]
+
+		pairs = append(pairs, tagPair{key: key, value: value})
+	}
+}
+
+func validateTagValue(key, value string, options map[string]bool) string {
+	parts := strings.Split(value, ",")
+	name := parts[0]
+
+	switch key {
+	case "db":
+		if len(parts) > 1 {
+			return "options are not supported"
+		}
+		if name != "-" && !isColumnName(name) {
+			return "invalid column name " + strconv.Quote(name)
+		}
+		return ""
+	default:
+		if strings.ContainsAny(name, " \t\"\\") {
+			return "invalid name " + strconv.Quote(name)
+		}
+	}
+
+	for _, option := range parts[1:] {
+		if !options[option] {
+			return "unknown option " + strconv.Quote(option)
+		}
+	}
+	return ""
+}
+
+func isColumnName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_' || r == '.':
+		case 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z':
+		case '0' <= r && r <= '9' && i != 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// UnreadFields returns the fields of the struct that are never read within the package.
+// Taking the address of a field counts as read and write.
+func (s *StructType) UnreadFields() []*Field {
+	reads, _ := s.fieldUsage()
+
+	var res []*Field
+	for _, field := range s.StructFields() {
+		if v := field.Var(); v != nil && !reads[v] {
+			res = append(res, field)
+		}
+	}
+	return res
+}
+
+// UnwrittenFields returns the fields of the struct that are never written within the package.
+// Fields set in composite literals or modified via increment or address taking count as written.
+func (s *StructType) UnwrittenFields() []*Field {
+	_, writes := s.fieldUsage()
+
+	var res []*Field
+	for _, field := range s.StructFields() {
+		if v := field.Var(); v != nil && !writes[v] {
+			res = append(res, field)
+		}
+	}
+	return res
+}
+
+func (s *StructType) fieldUsage() (reads, writes map[*types.Var]bool) {
+	reads, writes = map[*types.Var]bool{}, map[*types.Var]bool{}
+	if s.Pkg() == nil || s.Info() == nil {
+		return reads, writes
+	}
+
+	fields := map[*types.Var]bool{}
+	for _, field := range s.StructFields() {
+		if v := field.Var(); v != nil {
+			fields[v] = true
+		}
+	}
+	structType, _ := s.Info().TypeOf(s.StructType).(*types.Struct)
+
+	info := s.Info()
+	s.Pkg().Walk(func(node Node) bool {
+		switch n := node.(type) {
+		case *Ident:
+			v, ok := info.Uses[n.Ident].(*types.Var)
+			if !ok || !fields[v] {
+				return true
+			}
+			read, write := fieldAccess(n)
+			reads[v] = reads[v] || read
+			writes[v] = writes[v] || write
+		case *SelectorExpr:
+			// selecting promoted fields or methods reads the embedded fields on the way
+			for _, v := range embeddedPath(info.Selections[n.SelectorExpr]) {
+				if fields[v] {
+					reads[v] = true
+				}
+			}
+		case *CompositeLit:
+			// unkeyed composite literals write all fields
+			if structType == nil || len(n.CompositeLit.Elts) == 0 {
+				return true
+			}
+			if _, keyed := n.CompositeLit.Elts[0].(*ast.KeyValueExpr); keyed {
+				return true
+			}
+			typ := info.TypeOf(n.CompositeLit)
+			if typ == nil {
+				return true
+			}
+			if t, ok := typ.Underlying().(*types.Struct); ok && t == structType {
+				for i := 0; i < t.NumFields(); i++ {
+					writes[t.Field(i)] = true
+				}
+			}
+		}
+		return true
+	})
+	return reads, writes
+}
+
+// embeddedPath returns the embedded fields passed implicitly by the selection.
+func embeddedPath(sel *types.Selection) []*types.Var {
+	if sel == nil || len(sel.Index()) < 2 {
+		return nil
+	}
+
+	var (
+		res []*types.Var
+		t   = sel.Recv()
+	)
+	for _, idx := range sel.Index()[:len(sel.Index())-1] {
+		if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			return res
+		}
+		field := st.Field(idx)
+		res = append(res, field)
+		t = field.Type()
+	}
+	return res
+}
+
+// fieldAccess determines if the usage of a field via the given ident reads or writes it.
+func fieldAccess(ident *Ident) (read, write bool) {
+	var expr Node = ident
+	parent := ident.Parent()
+	if kv, ok := parent.(*KeyValueExpr); ok && kv.KeyValueExpr.Key == ident.Ident {
+		return false, true
+	}
+	if sel, ok := parent.(*SelectorExpr); ok {
+		expr = sel
+		parent = sel.Parent()
+	}
+	for {
+		paren, ok := parent.(*ParenExpr)
+		if !ok {
+			break
+		}
+		expr = paren
+		parent = paren.Parent()
+	}
+
+	switch p := parent.(type) {
+	case *AssignStmt:
+		for _, lhs := range p.AssignStmt.Lhs {
+			if lhs == expr.AstNode() {
+				return p.AssignStmt.Tok != token.ASSIGN && p.AssignStmt.Tok != token.DEFINE, true
+			}
+		}
+	case *IncDecStmt:
+		return true, true
+	case *UnaryExpr:
+		if p.UnaryExpr.Op == token.AND {
+			return true, true
+		}
+	case *RangeStmt:
+		if p.RangeStmt.Key == expr.AstNode() || p.RangeStmt.Value == expr.AstNode() {
+			return false, true
+		}
+	}
+	return true, false
+}
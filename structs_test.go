@@ -0,0 +1,94 @@
+package astrav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldNames(fields []*Field) []string {
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Name())
+	}
+	return names
+}
+
+func TestTypeSpec_StructFields(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/structs")
+
+	point := pkg.FindFirstByName("Point").(*TypeSpec)
+	assert.Equal(t, []string{"X", "Y", "Label", "hidden", "unused", "Base", "Meta", "Broken", "Column", "Twice"},
+		fieldNames(point.StructFields()))
+	assert.Equal(t, []string{"Base", "Meta"}, fieldNames(point.StructType().EmbeddedFields()))
+
+	y := point.StructType().FieldByName("Y")
+	assert.Equal(t, "x", y.TagName("json"))
+	assert.Equal(t, "int", y.Var().Type().String())
+
+	label := point.StructType().FieldByName("Label")
+	value, ok := label.LookupTag("json")
+	assert.True(t, ok)
+	assert.Equal(t, "label,omitempty", value)
+	_, ok = label.LookupTag("db")
+	assert.False(t, ok)
+
+	meta := point.StructType().FieldByName("Meta")
+	assert.True(t, meta.IsEmbedded())
+	assert.True(t, meta.Var().Embedded())
+}
+
+func TestStructType_ValidateTags(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/structs")
+
+	point := pkg.FindFirstByName("Point").(*TypeSpec).StructType()
+	var errs []string
+	for _, err := range point.ValidateTags() {
+		errs = append(errs, err.Error())
+	}
+	assert.Equal(t, []string{
+		`field Y: tag json: duplicate name "x"`,
+		`field Broken: bad syntax for struct tag pair`,
+		`field Column: tag db: invalid column name "bad name"`,
+		`field Column: tag json: unknown option "inline"`,
+		`field Twice: tag json: duplicate key`,
+	}, errs)
+
+	base := pkg.FindFirstByName("Base").(*TypeSpec).StructType()
+	assert.Empty(t, base.ValidateTags())
+
+	record := pkg.FindFirstByName("Record").(*TypeSpec).StructType()
+	for i := 0; i < 10; i++ {
+		errs = errs[:0]
+		for _, err := range record.ValidateTags() {
+			errs = append(errs, err.Error())
+		}
+		assert.Equal(t, []string{
+			`field B: tag db: duplicate name "a"`,
+			`field B: tag json: duplicate name "a"`,
+			`field B: tag yaml: duplicate name "a"`,
+		}, errs)
+	}
+}
+
+func TestStructType_FieldUsage_MissingType(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/structs")
+
+	pair := pkg.FindFirstByName("Pair").(*TypeSpec).StructType()
+	assert.Empty(t, fieldNames(pair.UnwrittenFields()))
+
+	// ill-typed code can miss the type of a composite literal
+	lit := pkg.(*Package).FuncDeclByName("newPair").FindFirstByNodeType(NodeTypeCompositeLit).(*CompositeLit)
+	delete(pkg.Info().Types, lit.CompositeLit)
+	assert.NotPanics(t, func() {
+		assert.Equal(t, []string{"A", "B"}, fieldNames(pair.UnwrittenFields()))
+	})
+}
+
+func TestStructType_FieldUsage(t *testing.T) {
+	pkg := getPackageFromPath(t, "testdata/structs")
+
+	point := pkg.FindFirstByName("Point").(*TypeSpec).StructType()
+	assert.Equal(t, []string{"Label", "unused", "Broken", "Column"}, fieldNames(point.UnreadFields()))
+	assert.Equal(t, []string{"unused", "Base", "Twice"}, fieldNames(point.UnwrittenFields()))
+}
@@ -0,0 +1,45 @@
+package calls
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shape has an area.
+type Shape interface {
+	Area() float64
+}
+
+// Square is a square.
+type Square struct {
+	Side float64
+}
+
+// Area returns the area of the square.
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+func total(shapes []Shape) float64 {
+	var sum float64
+	for _, shape := range shapes {
+		sum += shape.Area()
+	}
+	return sum
+}
+
+// Run runs all kinds of calls.
+func Run() {
+	shapes := make([]Shape, 0, 2)
+	shapes = append(shapes, Square{Side: 2})
+	n := int64(len(shapes))
+	sq := Square{Side: 3}
+	area := sq.Area()
+	double := func(v float64) float64 { return 2 * v }
+	area = double(area)
+	area += (Square).Area(sq)
+	area += total(shapes)
+	func() {
+		fmt.Println(strings.Repeat("x", int(n)), area)
+	}()
+}
@@ -0,0 +1,50 @@
+package closures
+
+import "sort"
+
+var handlers []func()
+
+func counter() func() int {
+	count := 0
+	return func() int {
+		count++
+		return count
+	}
+}
+
+func sum(values []int) int {
+	total := 0
+	add := func(v int) {
+		total += v
+	}
+	for _, v := range values {
+		add(v)
+	}
+
+	limit := len(values)
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] < values[j] && j < limit
+	})
+
+	func() {
+		total *= 2
+	}()
+
+	defer func() {
+		handlers = nil
+	}()
+
+	handler := func() {
+		_ = limit
+	}
+	handlers = append(handlers, handler)
+
+	var ptr *int
+	go func() {
+		ptr = &total
+		inner := func() int { return limit }
+		_ = inner()
+	}()
+	_ = ptr
+	return total
+}
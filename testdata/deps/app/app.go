@@ -0,0 +1,8 @@
+package app
+
+import "example.com/lib/strutil"
+
+// Greeting returns a reversed greeting.
+func Greeting(name string) string {
+	return strutil.Reverse("hello " + name)
+}
@@ -0,0 +1,16 @@
+package strutil
+
+import "strings"
+
+// Reverse returns s in upper case with its runes in reverse order.
+func Reverse(s string) string {
+	runes := []rune(upper(s))
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+func upper(s string) string {
+	return strings.ToUpper(s)
+}
@@ -0,0 +1,15 @@
+package imports
+
+import (
+	_ "embed"
+	"fmt"
+	. "math"
+	str "strings"
+)
+
+// Describe describes the value.
+func Describe(value float64) string {
+	var b str.Builder
+	b.WriteString(fmt.Sprint(Sqrt(value)))
+	return str.ToUpper(b.String())
+}
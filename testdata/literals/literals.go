@@ -0,0 +1,26 @@
+package literals
+
+import "strings"
+
+// Point is a point.
+type Point struct {
+	X, Y  int
+	Label string
+	Tags  []string
+}
+
+const scale = 2
+
+var points = []*Point{
+	{X: 1, Y: 2 * scale, Label: "a", Tags: []string{"x", "y"}},
+	{3, 4, "b", nil},
+}
+
+var weights = [5]float64{1: 0.5, 3: 1.5, 2.5}
+
+var grid = map[string][2]bool{
+	"first":  {true, false},
+	"second": {false, true},
+}
+
+var dynamic = []string{strings.ToUpper("a"), "b"}
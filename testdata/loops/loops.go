@@ -0,0 +1,46 @@
+package loops
+
+import "strings"
+
+type counter struct {
+	count int
+}
+
+func (c *counter) inc() {
+	c.count++
+}
+
+func process(items []string, prefix string, limit int) (int, []string) {
+	var (
+		total int
+		out   []string
+		c     counter
+	)
+	for i, item := range items {
+		n := len(prefix) * 2
+		if i > limit {
+			break
+		}
+		total += n + len(item)
+		out = append(out, strings.ToUpper(item))
+		for _, other := range items {
+			if other == item {
+				total++
+			}
+			buf := make([]byte, limit)
+			_ = buf
+		}
+		c.inc()
+	}
+
+	for j := 0; j < len(items); j++ {
+		if check(items[j], prefix+"x") {
+			return j, []string{items[j]}
+		}
+	}
+	return total + c.count, out
+}
+
+func check(s, substr string) bool {
+	return strings.Contains(s, substr)
+}
@@ -0,0 +1,8 @@
+package render
+
+import "github.com/tehsphinx/astrav/testdata/module/shapes"
+
+// Describe returns the area of a square with the given side.
+func Describe(side float64) float64 {
+	return shapes.Square{Side: side}.Area()
+}
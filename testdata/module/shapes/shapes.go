@@ -0,0 +1,11 @@
+package shapes
+
+// Square is a square shape.
+type Square struct {
+	Side float64
+}
+
+// Area returns the area of the square.
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
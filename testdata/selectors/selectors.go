@@ -0,0 +1,38 @@
+package selectors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Named has a name.
+type Named interface {
+	Name() string
+}
+
+// Base is embedded into Item.
+type Base struct {
+	ID int
+}
+
+// Describe describes the base.
+func (b Base) Describe() string {
+	return strings.Repeat("x", b.ID)
+}
+
+// Item is an item.
+type Item struct {
+	Base
+	Label string
+}
+
+// Name returns the name of the item.
+func (i *Item) Name() string {
+	return i.Label
+}
+
+func use(i *Item, n Named) []string {
+	name := (*Item).Name
+	describe := i.Describe
+	return []string{name(i), describe(), n.Name(), strings.ToUpper(i.Label), strconv.Itoa(i.ID), i.Base.Describe()}
+}
@@ -0,0 +1,63 @@
+package structs
+
+// Base is embedded into Point.
+type Base struct {
+	ID int `db:"id"`
+}
+
+// Meta is embedded into Point as pointer.
+type Meta struct {
+	Tags []string
+}
+
+// Point has fields with and without tags.
+type Point struct {
+	X, Y   int    `json:"x"`
+	Label  string `json:"label,omitempty" yaml:"label"`
+	hidden bool
+	unused int
+	Base
+	*Meta  `yaml:",inline"`
+	Broken string `json:name`
+	Column string `db:"bad name" json:"column,omitempty,inline"`
+	Twice  string `json:"twice" json:"again"`
+}
+
+// NewPoint creates a point.
+func NewPoint() Point {
+	return Point{X: 1, Label: "point"}
+}
+
+// Move moves the point.
+func (p *Point) Move() {
+	p.Y++
+	p.hidden = true
+	p.ID = 3
+	p.Meta = &Meta{}
+	p.Broken, p.Column = "", ""
+}
+
+// Sum sums up the coordinates.
+func (p Point) Sum() int {
+	return p.X + p.Y + len(p.Tags) + len(p.Twice)
+}
+
+// Flag returns the address of the hidden flag.
+func (p *Point) Flag() *bool {
+	return &p.hidden
+}
+
+// Record uses the same name for all keys twice.
+type Record struct {
+	A string `db:"a" json:"a" yaml:"a"`
+	B string `yaml:"a" json:"a" db:"a"`
+}
+
+// Pair is written with an unkeyed literal only.
+type Pair struct {
+	A, B int
+}
+
+func newPair() Pair {
+	return Pair{1, 2}
+}
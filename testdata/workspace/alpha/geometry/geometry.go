@@ -0,0 +1,11 @@
+package geometry
+
+// Circle is a circle shape.
+type Circle struct {
+	Radius float64
+}
+
+// Area returns the area of the circle.
+func (c Circle) Area() float64 {
+	return 3.14 * c.Radius * c.Radius
+}
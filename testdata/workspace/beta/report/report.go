@@ -0,0 +1,12 @@
+package report
+
+import "example.com/alpha/geometry"
+
+// Total returns the area of all circles with the given radii.
+func Total(radii ...float64) float64 {
+	var total float64
+	for _, r := range radii {
+		total += geometry.Circle{Radius: r}.Area()
+	}
+	return total
+}
@@ -0,0 +1,253 @@
+package astrav
+
+import (
+	"go/types"
+)
+
+// TypePredicate checks if a type has a certain property. Predicates can be combined
+// with And, Or and Not.
+type TypePredicate func(t types.Type) bool
+
+var errorInterface = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// IsMap checks if the underlying type is a map.
+func IsMap(t types.Type) bool {
+	_, ok := underlying(t).(*types.Map)
+	return ok
+}
+
+// IsSlice checks if the underlying type is a slice.
+func IsSlice(t types.Type) bool {
+	_, ok := underlying(t).(*types.Slice)
+	return ok
+}
+
+// IsArray checks if the underlying type is an array.
+func IsArray(t types.Type) bool {
+	_, ok := underlying(t).(*types.Array)
+	return ok
+}
+
+// IsChan checks if the underlying type is a channel.
+func IsChan(t types.Type) bool {
+	_, ok := underlying(t).(*types.Chan)
+	return ok
+}
+
+// IsFunc checks if the underlying type is a function signature.
+func IsFunc(t types.Type) bool {
+	_, ok := underlying(t).(*types.Signature)
+	return ok
+}
+
+// IsStruct checks if the underlying type is a struct.
+func IsStruct(t types.Type) bool {
+	_, ok := underlying(t).(*types.Struct)
+	return ok
+}
+
+// IsInterface checks if the underlying type is an interface.
+func IsInterface(t types.Type) bool {
+	_, ok := underlying(t).(*types.Interface)
+	return ok
+}
+
+// IsError checks if the type implements the error interface.
+func IsError(t types.Type) bool {
+	return t != nil && types.Implements(t, errorInterface)
+}
+
+// IsPointer checks if the type is a pointer.
+func IsPointer(t types.Type) bool {
+	_, ok := underlying(t).(*types.Pointer)
+	return ok
+}
+
+// IsBasic checks if the underlying type is the given basic type. Untyped constants
+// match their default type, e.g. an untyped integer constant matches types.Int.
+func IsBasic(kind types.BasicKind) TypePredicate {
+	return func(t types.Type) bool {
+		if t == nil {
+			return false
+		}
+		basic, ok := types.Default(underlying(t)).(*types.Basic)
+		return ok && basic.Kind() == kind
+	}
+}
+
+// IsPointerTo checks if the type is a pointer and its element type matches the predicate.
+func IsPointerTo(elem TypePredicate) TypePredicate {
+	return func(t types.Type) bool {
+		ptr, ok := underlying(t).(*types.Pointer)
+		return ok && elem(ptr.Elem())
+	}
+}
+
+// IsSliceOf checks if the type is a slice and its element type matches the predicate.
+func IsSliceOf(elem TypePredicate) TypePredicate {
+	return func(t types.Type) bool {
+		slice, ok := underlying(t).(*types.Slice)
+		return ok && elem(slice.Elem())
+	}
+}
+
+// IsMapOf checks if the type is a map and key and element type match the predicates.
+// A nil predicate matches any type.
+func IsMapOf(key, elem TypePredicate) TypePredicate {
+	return func(t types.Type) bool {
+		m, ok := underlying(t).(*types.Map)
+		if !ok {
+			return false
+		}
+		return (key == nil || key(m.Key())) && (elem == nil || elem(m.Elem()))
+	}
+}
+
+// IsNamed checks if the type is the named type with given name declared in the package with
+// given import path. Aliases are resolved. Use an empty package path for predeclared types
+// like error.
+func IsNamed(pkgPath, name string) TypePredicate {
+	return func(t types.Type) bool {
+		if t == nil {
+			return false
+		}
+		named, ok := types.Unalias(t).(*types.Named)
+		if !ok {
+			return false
+		}
+
+		obj := named.Obj()
+		if obj.Name() != name {
+			return false
+		}
+		if obj.Pkg() == nil {
+			return pkgPath == ""
+		}
+		return obj.Pkg().Path() == pkgPath
+	}
+}
+
+// IsIdentical checks if the type is identical to the given type.
+func IsIdentical(other types.Type) TypePredicate {
+	return func(t types.Type) bool {
+		return t != nil && types.Identical(t, other)
+	}
+}
+
+// Underlying checks if the underlying type matches the predicate.
+func Underlying(pred TypePredicate) TypePredicate {
+	return func(t types.Type) bool {
+		return t != nil && pred(underlying(t))
+	}
+}
+
+// Implements checks if the type implements the given interface.
+func Implements(iface *types.Interface) TypePredicate {
+	return func(t types.Type) bool {
+		return t != nil && types.Implements(t, iface)
+	}
+}
+
+// AssignableTo checks if a value of the type is assignable to the given type.
+func AssignableTo(other types.Type) TypePredicate {
+	return func(t types.Type) bool {
+		return t != nil && types.AssignableTo(t, other)
+	}
+}
+
+// ConvertibleTo checks if a value of the type is convertible to the given type.
+func ConvertibleTo(other types.Type) TypePredicate {
+	return func(t types.Type) bool {
+		return t != nil && types.ConvertibleTo(t, other)
+	}
+}
+
+// And checks if the type matches all predicates.
+func And(preds ...TypePredicate) TypePredicate {
+	return func(t types.Type) bool {
+		for _, pred := range preds {
+			if !pred(t) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or checks if the type matches any of the predicates.
+func Or(preds ...TypePredicate) TypePredicate {
+	return func(t types.Type) bool {
+		for _, pred := range preds {
+			if pred(t) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not negates a predicate.
+func Not(pred TypePredicate) TypePredicate {
+	return func(t types.Type) bool {
+		return !pred(t)
+	}
+}
+
+func underlying(t types.Type) types.Type {
+	if t == nil {
+		return nil
+	}
+	return t.Underlying()
+}
+
+// HasValueType checks if the value type of the node matches the predicate.
+func (s *baseNode) HasValueType(pred TypePredicate) bool {
+	t := s.realMe.ValueType()
+	return t != nil && pred(t)
+}
+
+// FindByType finds all nodes whose value type matches the predicate.
+func (s *baseNode) FindByType(pred TypePredicate) []Node {
+	return s.TreeNodes(func(n Node) bool {
+		return n.HasValueType(pred)
+	})
+}
+
+// LookupType looks up a type by name. An empty package path or the path of the package itself
+// searches the package, otherwise the imports of the package are searched.
+func (s *Package) LookupType(pkgPath, name string) types.Type {
+	if s.typesPkg == nil {
+		return nil
+	}
+
+	pkg := s.typesPkg
+	if pkgPath != "" && pkgPath != pkg.Path() {
+		pkg = findImport(pkg, pkgPath, map[*types.Package]bool{})
+		if pkg == nil {
+			return nil
+		}
+	}
+
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	return obj.Type()
+}
+
+func findImport(pkg *types.Package, pkgPath string, visited map[*types.Package]bool) *types.Package {
+	if visited[pkg] {
+		return nil
+	}
+	visited[pkg] = true
+
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == pkgPath {
+			return imp
+		}
+		if found := findImport(imp, pkgPath, visited); found != nil {
+			return found
+		}
+	}
+	return nil
+}
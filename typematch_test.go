@@ -0,0 +1,77 @@
+package astrav
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseNode_FindByTypePredicate(t *testing.T) {
+	pkg := getPackage(t, 8)
+
+	nodes := pkg.FindByType(IsMapOf(IsBasic(types.String), IsBasic(types.Int)))
+	assert.Equal(t, 3, len(nodes))
+	nodes = pkg.FindByType(IsMapOf(IsBasic(types.Int), nil))
+	assert.Equal(t, 0, len(nodes))
+
+	nodes = pkg.FindByType(IsBasic(types.Int32))
+	for _, node := range nodes {
+		assert.Equal(t, "rune", node.ValueType().String())
+	}
+	assert.Equal(t, 2, len(nodes))
+}
+
+func TestBaseNode_HasValueType(t *testing.T) {
+	pkg := getPackage(t, 7)
+
+	ident := pkg.FindFirstIdentByName("diff")
+	assert.True(t, ident.HasValueType(IsBasic(types.Int)))
+	assert.False(t, ident.HasValueType(IsError))
+
+	lit := pkg.FindByToken(token.AND)[0]
+	assert.True(t, lit.HasValueType(IsError))
+	assert.True(t, lit.HasValueType(IsPointerTo(IsNamed("", "NotEqualLengthError"))))
+	assert.True(t, lit.HasValueType(IsPointerTo(IsStruct)))
+	assert.False(t, lit.HasValueType(Not(IsPointer)))
+}
+
+func TestTypePredicates(t *testing.T) {
+	pkg := types.NewPackage("example.com/p", "p")
+	myInt := types.NewNamed(types.NewTypeName(0, pkg, "MyInt", nil), types.Typ[types.Int], nil)
+	alias := types.NewAlias(types.NewTypeName(0, pkg, "Alias", nil), myInt)
+	stringer := types.NewInterfaceType([]*types.Func{
+		types.NewFunc(0, pkg, "String", types.NewSignatureType(nil, nil, nil, nil,
+			types.NewTuple(types.NewVar(0, pkg, "", types.Typ[types.String])), false)),
+	}, nil).Complete()
+
+	assert.True(t, IsBasic(types.Int)(myInt))
+	assert.True(t, IsBasic(types.Int)(types.Typ[types.UntypedInt]))
+	assert.True(t, IsNamed("example.com/p", "MyInt")(alias))
+	assert.False(t, IsNamed("other.com/p", "MyInt")(myInt))
+	assert.True(t, IsNamed("", "error")(types.Universe.Lookup("error").Type()))
+	assert.True(t, Underlying(IsIdentical(types.Typ[types.Int]))(alias))
+	assert.True(t, IsSliceOf(IsNamed("example.com/p", "MyInt"))(types.NewSlice(alias)))
+	assert.True(t, ConvertibleTo(types.Typ[types.Int])(myInt))
+	assert.False(t, AssignableTo(types.Typ[types.Int])(myInt))
+	assert.False(t, Implements(stringer)(myInt))
+	assert.True(t, Or(IsMap, IsSlice)(types.NewSlice(myInt)))
+	assert.False(t, And(IsSlice, IsSliceOf(IsBasic(types.String)))(types.NewSlice(myInt)))
+	assert.False(t, IsMap(nil))
+}
+
+func TestPackage_LookupType(t *testing.T) {
+	pkg := getPackage(t, 7).(*Package)
+
+	typ := pkg.LookupType("", "NotEqualLengthError")
+	assert.NotNil(t, typ)
+	assert.True(t, IsError(types.NewPointer(typ)))
+	assert.False(t, IsError(typ))
+
+	stringer := pkg.LookupType("fmt", "Stringer")
+	assert.NotNil(t, stringer)
+	assert.True(t, IsInterface(stringer))
+	assert.Nil(t, pkg.LookupType("fmt", "Missing"))
+	assert.Nil(t, pkg.LookupType("unknown", "Stringer"))
+}
@@ -13,12 +13,13 @@ import (
 // from the Nodes. When using Module.ParseFolder, this is done automatically.
 func (s *Folder) ParseInfo(path string, fSet *token.FileSet, files []*ast.File) (*types.Package, error) {
 	s.Info = &types.Info{
-		Types:      map[ast.Expr]types.TypeAndValue{},
-		Defs:       map[*ast.Ident]types.Object{},
-		Uses:       map[*ast.Ident]types.Object{},
-		Scopes:     map[ast.Node]*types.Scope{},
-		Implicits:  map[ast.Node]types.Object{},
-		Selections: map[*ast.SelectorExpr]*types.Selection{},
+		Types:        map[ast.Expr]types.TypeAndValue{},
+		Defs:         map[*ast.Ident]types.Object{},
+		Uses:         map[*ast.Ident]types.Object{},
+		Scopes:       map[ast.Node]*types.Scope{},
+		Implicits:    map[ast.Node]types.Object{},
+		Selections:   map[*ast.SelectorExpr]*types.Selection{},
+		FileVersions: map[*ast.File]string{},
 	}
 	var conf = types.Config{
 		Importer: importer.Default(),
@@ -29,8 +30,9 @@ func (s *Folder) ParseInfo(path string, fSet *token.FileSet, files []*ast.File)
 		return nil, errors.WithStack(err)
 	}
 
-	for _, pkg := range s.Pkgs {
-		pkg.info = s.Info
+	for _, p := range s.Pkgs {
+		p.info = s.Info
+		p.typesPkg = pkg
 	}
 
 	return pkg, nil
@@ -39,61 +41,7 @@ func (s *Folder) ParseInfo(path string, fSet *token.FileSet, files []*ast.File)
 // NodeType defines a node type string to search for type
 type NodeType string
 
-// Nodetype contants
-const (
-	NodeTypeComment        NodeType = "*astrav.Comment"
-	NodeTypeCommentGroup   NodeType = "*astrav.CommentGroup"
-	NodeTypeField          NodeType = "*astrav.Field"
-	NodeTypeFieldList      NodeType = "*astrav.FieldList"
-	NodeTypeBadExpr        NodeType = "*astrav.BadExpr"
-	NodeTypeIdent          NodeType = "*astrav.Ident"
-	NodeTypeEllipsis       NodeType = "*astrav.Ellipsis"
-	NodeTypeBasicLit       NodeType = "*astrav.BasicLit"
-	NodeTypeFuncLit        NodeType = "*astrav.FuncLit"
-	NodeTypeCompositeLit   NodeType = "*astrav.CompositeLit"
-	NodeTypeParenExpr      NodeType = "*astrav.ParenExpr"
-	NodeTypeSelectorExpr   NodeType = "*astrav.SelectorExpr"
-	NodeTypeIndexExpr      NodeType = "*astrav.IndexExpr"
-	NodeTypeSliceExpr      NodeType = "*astrav.SliceExpr"
-	NodeTypeTypeAssertExpr NodeType = "*astrav.TypeAssertExpr"
-	NodeTypeCallExpr       NodeType = "*astrav.CallExpr"
-	NodeTypeStarExpr       NodeType = "*astrav.StarExpr"
-	NodeTypeUnaryExpr      NodeType = "*astrav.UnaryExpr"
-	NodeTypeBinaryExpr     NodeType = "*astrav.BinaryExpr"
-	NodeTypeKeyValueExpr   NodeType = "*astrav.KeyValueExpr"
-	NodeTypeArrayType      NodeType = "*astrav.ArrayType"
-	NodeTypeStructType     NodeType = "*astrav.StructType"
-	NodeTypeFuncType       NodeType = "*astrav.FuncType"
-	NodeTypeInterfaceType  NodeType = "*astrav.InterfaceType"
-	NodeTypeMapType        NodeType = "*astrav.MapType"
-	NodeTypeChanType       NodeType = "*astrav.ChanType"
-	NodeTypeBadStmt        NodeType = "*astrav.BadStmt"
-	NodeTypeDeclStmt       NodeType = "*astrav.DeclStmt"
-	NodeTypeEmptyStmt      NodeType = "*astrav.EmptyStmt"
-	NodeTypeLabeledStmt    NodeType = "*astrav.LabeledStmt"
-	NodeTypeExprStmt       NodeType = "*astrav.ExprStmt"
-	NodeTypeSendStmt       NodeType = "*astrav.SendStmt"
-	NodeTypeIncDecStmt     NodeType = "*astrav.IncDecStmt"
-	NodeTypeAssignStmt     NodeType = "*astrav.AssignStmt"
-	NodeTypeGoStmt         NodeType = "*astrav.GoStmt"
-	NodeTypeDeferStmt      NodeType = "*astrav.DeferStmt"
-	NodeTypeReturnStmt     NodeType = "*astrav.ReturnStmt"
-	NodeTypeBranchStmt     NodeType = "*astrav.BranchStmt"
-	NodeTypeBlockStmt      NodeType = "*astrav.BlockStmt"
-	NodeTypeIfStmt         NodeType = "*astrav.IfStmt"
-	NodeTypeCaseClause     NodeType = "*astrav.CaseClause"
-	NodeTypeSwitchStmt     NodeType = "*astrav.SwitchStmt"
-	NodeTypeTypeSwitchStmt NodeType = "*astrav.TypeSwitchStmt"
-	NodeTypeCommClause     NodeType = "*astrav.CommClause"
-	NodeTypeSelectStmt     NodeType = "*astrav.SelectStmt"
-	NodeTypeForStmt        NodeType = "*astrav.ForStmt"
-	NodeTypeRangeStmt      NodeType = "*astrav.RangeStmt"
-	NodeTypeImportSpec     NodeType = "*astrav.ImportSpec"
-	NodeTypeValueSpec      NodeType = "*astrav.ValueSpec"
-	NodeTypeTypeSpec       NodeType = "*astrav.TypeSpec"
-	NodeTypeBadDecl        NodeType = "*astrav.BadDecl"
-	NodeTypeGenDecl        NodeType = "*astrav.GenDecl"
-	NodeTypeFuncDecl       NodeType = "*astrav.FuncDecl"
-	NodeTypeFile           NodeType = "*astrav.File"
-	NodeTypePackage        NodeType = "*astrav.Package"
-)
+// Valid reports whether the node type is one of the NodeType constants.
+func (s NodeType) Valid() bool {
+	return nodeTypes[s]
+}
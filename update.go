@@ -0,0 +1,570 @@
+package astrav
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// UpdateFile replaces the source of a single file of a loaded module. Only that file is parsed again.
+// Its package and all module packages importing it (directly or indirectly) are type-checked again and
+// their nodes are rebuilt. All other packages keep their nodes and caches. Only the SSA packages of the
+// re-analyzed packages are built again: the functions of the replaced packages are removed from the call
+// graph and the new functions are added with their calls. The SSA program and the call graph are built
+// from scratch once too many replaced packages piled up in the program. The import paths of the
+// re-analyzed packages are returned in sorted order.
+//
+// The file must already be part of a loaded package and may only import packages that package already
+// depends on. Adding files or dependencies requires a full Load. Type errors do not fail the update:
+// they are recorded on the packages.Package just like Load does. Ill-typed packages are not built as SSA.
+func (s *Module) UpdateFile(filePath string, src []byte) ([]string, error) {
+	fileName, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to resolve %s", filePath)
+	}
+
+	pack, index := s.packageOfFile(fileName)
+	if pack == nil {
+		return nil, errors.Errorf("file %s is not part of the module", filePath)
+	}
+
+	base := s.FSet.Base()
+	file, err := parser.ParseFile(s.FSet, fileName, src, parser.AllErrors|parser.ParseComments)
+	if err != nil {
+		s.removeFile(token.Pos(base))
+		return nil, errors.WithMessagef(err, "failed parsing %s", filePath)
+	}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if _, ok := pack.Imports[importPath]; !ok && importPath != "unsafe" && importPath != "C" {
+			s.removeFile(token.Pos(base))
+			return nil, errors.Errorf("%s imports %s which is not loaded: reload the module", filePath, importPath)
+		}
+	}
+
+	// the replaced file is not referenced anymore once all affected packages are rebuilt
+	s.removeFile(pack.Syntax[index].Pos())
+
+	syntax := make([]*ast.File, len(pack.Syntax))
+	copy(syntax, pack.Syntax)
+	syntax[index] = file
+	pack.Syntax = syntax
+
+	s.RawFiles[fileName] = &RawFile{
+		File:   s.FSet.File(file.Pos()),
+		source: src,
+	}
+
+	affected := s.dependents(pack)
+	changed := make([]string, 0, len(affected))
+	superseded := make(map[*types.Package]bool, len(affected))
+	for _, p := range affected {
+		if p.Types != nil {
+			superseded[p.Types] = true
+		}
+		s.typeCheck(p)
+		if r := s.processPackage(p); r != nil {
+			return nil, r
+		}
+		changed = append(changed, p.PkgPath)
+	}
+
+	s.updateCallGraph(affected, superseded)
+
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// removeFile removes the file containing pos from the FileSet.
+func (s *Module) removeFile(pos token.Pos) {
+	if file := s.FSet.File(pos); file != nil {
+		s.FSet.RemoveFile(file)
+	}
+}
+
+// maxSuperseded is the number of superseded packages the SSA program may contain. Once exceeded, the
+// program and the call graph are built from scratch so the superseded packages can be freed.
+const maxSuperseded = 16
+
+// updateCallGraph builds the SSA packages of the given type-checked packages and updates the call graph.
+// The functions of the superseded packages are removed from the graph, the functions of the new SSA
+// packages are added. Only the calls of the new functions are resolved, plus the existing dynamic calls
+// that might call one of the new functions. Dynamic calls are resolved just like cha.CallGraph does.
+// Once too many superseded packages piled up, the program is built from scratch: the call graph is computed
+// again, or transferred to the new program if the dependencies were loaded from export data.
+func (s *Module) updateCallGraph(packs []*packages.Package, superseded map[*types.Package]bool) {
+	if s.program == nil || s.Graph == nil {
+		s.buildCallGraph()
+		return
+	}
+	for pkg := range superseded {
+		s.superseded[pkg] = true
+	}
+	if len(s.superseded) > maxSuperseded && !s.exportedDeps {
+		s.buildCallGraph()
+		return
+	}
+
+	ssaPkgs := make(map[*packages.Package]*ssa.Package, len(packs))
+	newPkgs := make([]*ssa.Package, 0, len(packs))
+	for _, pack := range packs {
+		ssaPkgs[pack] = nil
+		if pack.Types != nil && !pack.IllTyped {
+			ssaPkg := s.program.CreatePackage(pack.Types, pack.Syntax, pack.TypesInfo, true)
+			ssaPkgs[pack] = ssaPkg
+			newPkgs = append(newPkgs, ssaPkg)
+		}
+	}
+	for _, ssaPkg := range newPkgs {
+		ssaPkg.Build()
+	}
+	for i, pack := range s.Packages {
+		if ssaPkg, ok := ssaPkgs[pack]; ok {
+			s.SSAPkgs[i] = ssaPkg
+		}
+	}
+
+	if s.calls == nil {
+		s.calls = newCallIndex(s.Graph, s.superseded)
+	} else {
+		s.calls.remove(superseded)
+	}
+
+	// calls made by dependencies loaded from export data have no call site and can not be resolved again:
+	// they are transferred to the new function of the same name.
+	type transfer struct {
+		caller *callgraph.Node
+		callee string
+	}
+	var (
+		maxID     int
+		transfers []transfer
+	)
+	for fn, node := range s.Graph.Nodes {
+		if fn != nil && isSuperseded(fn, superseded) {
+			for _, edge := range node.In {
+				if edge.Site == nil && edge.Caller.Func != nil && !isSuperseded(edge.Caller.Func, superseded) {
+					transfers = append(transfers, transfer{caller: edge.Caller, callee: fn.String()})
+				}
+			}
+			s.Graph.DeleteNode(node)
+			continue
+		}
+		maxID = max(maxID, node.ID)
+	}
+	nodeOf := func(fn *ssa.Function) *callgraph.Node {
+		node, ok := s.Graph.Nodes[fn]
+		if !ok {
+			maxID++
+			node = &callgraph.Node{Func: fn, ID: maxID}
+			s.Graph.Nodes[fn] = node
+		}
+		return node
+	}
+
+	newFuncs := functionsOf(s.program, newPkgs, func(fn *ssa.Function) bool {
+		_, ok := s.Graph.Nodes[fn]
+		return ok || isSuperseded(fn, s.superseded)
+	})
+	for fn := range newFuncs {
+		s.calls.add(fn)
+	}
+	for fn := range newFuncs {
+		node := nodeOf(fn)
+		forEachCall(fn, func(site ssa.CallInstruction) {
+			if callee := site.Common().StaticCallee(); callee != nil {
+				callgraph.AddEdge(node, site, nodeOf(callee))
+				return
+			}
+			for _, callee := range s.calls.callees(site) {
+				callgraph.AddEdge(node, site, nodeOf(callee))
+			}
+		})
+	}
+	for fn := range newFuncs {
+		for _, call := range s.calls.callers(fn) {
+			if !newFuncs[call.caller] {
+				callgraph.AddEdge(nodeOf(call.caller), call.site, nodeOf(fn))
+			}
+		}
+	}
+
+	if len(transfers) != 0 {
+		byName := make(map[string]*ssa.Function, len(newFuncs))
+		for fn := range newFuncs {
+			byName[fn.String()] = fn
+		}
+		for _, t := range transfers {
+			if fn, ok := byName[t.callee]; ok {
+				callgraph.AddEdge(t.caller, nil, nodeOf(fn))
+			}
+		}
+	}
+
+	if len(s.superseded) > maxSuperseded {
+		// the calls of dependencies loaded from export data can not be computed again, the call graph is
+		// transferred to the new program instead
+		graph := s.encodeCallGraph()
+		s.buildProgram()
+		s.Graph = s.restoreCallGraph(graph)
+	}
+}
+
+// functionsOf returns the functions of the given SSA packages just like ssautil.AllFunctions finds them: the
+// package functions, the methods of exported types and of types converted to interfaces, and all functions
+// they reference. Functions for which skip reports true are left out.
+func functionsOf(program *ssa.Program, ssaPkgs []*ssa.Package, skip func(fn *ssa.Function) bool) map[*ssa.Function]bool {
+	fns := map[*ssa.Function]bool{}
+
+	var visit func(fn *ssa.Function)
+	visit = func(fn *ssa.Function) {
+		if fn == nil || fns[fn] || (skip != nil && skip(fn)) {
+			return
+		}
+		fns[fn] = true
+
+		var operands [10]*ssa.Value
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				for _, op := range instr.Operands(operands[:0]) {
+					if ref, ok := (*op).(*ssa.Function); ok {
+						visit(ref)
+					}
+				}
+			}
+		}
+	}
+	methodsOf := func(t types.Type) {
+		if types.IsInterface(t) {
+			return
+		}
+		for sel := range program.MethodSets.MethodSet(t).Methods() {
+			if sel.Obj().(*types.Func).Signature().TypeParams() == nil {
+				visit(program.MethodValue(sel))
+			}
+		}
+	}
+
+	for _, ssaPkg := range ssaPkgs {
+		if ssaPkg == nil {
+			continue
+		}
+		for _, member := range ssaPkg.Members {
+			switch member := member.(type) {
+			case *ssa.Function:
+				visit(member)
+			case *ssa.Type:
+				named, ok := member.Type().(*types.Named)
+				if ok && ast.IsExported(member.Name()) && named.TypeParams() == nil {
+					methodsOf(named)
+					methodsOf(types.NewPointer(named))
+				}
+			}
+		}
+	}
+	for _, t := range program.RuntimeTypes() {
+		methodsOf(t)
+	}
+	return fns
+}
+
+// isSuperseded checks if the function belongs to one of the superseded packages: it is declared in one
+// of them, wraps a method of one of their types or is an instance using one of their types.
+func isSuperseded(fn *ssa.Function, superseded map[*types.Package]bool) bool {
+	if fn.Pkg != nil && superseded[fn.Pkg.Pkg] {
+		return true
+	}
+	if recv := fn.Signature.Recv(); recv != nil && superseded[typePackage(recv.Type())] {
+		return true
+	}
+	if obj := fn.Object(); obj != nil && superseded[obj.Pkg()] {
+		return true
+	}
+	for _, arg := range fn.TypeArgs() {
+		if superseded[typePackage(arg)] {
+			return true
+		}
+	}
+	return false
+}
+
+// typePackage returns the package declaring the named type or the named type a pointer points to.
+func typePackage(t types.Type) *types.Package {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		return named.Obj().Pkg()
+	}
+	return nil
+}
+
+func forEachCall(fn *ssa.Function, f func(site ssa.CallInstruction)) {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			if site, ok := instr.(ssa.CallInstruction); ok {
+				f(site)
+			}
+		}
+	}
+}
+
+// callIndex indexes the functions of the call graph and its dynamic calls to resolve dynamic calls
+// using class hierarchy analysis. It matches the resolution of cha.CallGraph: interface method calls
+// may call the methods of all types implementing the interface, dynamic function calls all functions
+// with an identical signature.
+type callIndex struct {
+	funcsBySig  typeutil.Map // *types.Signature -> []*ssa.Function
+	methodsByID map[string][]*ssa.Function
+	funcCalls   typeutil.Map // *types.Signature -> []dynamicCall
+	invokes     map[string][]dynamicCall
+}
+
+// dynamicCall is a call site without static callee.
+type dynamicCall struct {
+	caller *ssa.Function
+	site   ssa.CallInstruction
+}
+
+// newCallIndex indexes all functions of the call graph except those of superseded packages.
+func newCallIndex(graph *callgraph.Graph, superseded map[*types.Package]bool) *callIndex {
+	index := &callIndex{
+		methodsByID: map[string][]*ssa.Function{},
+		invokes:     map[string][]dynamicCall{},
+	}
+	for fn := range graph.Nodes {
+		if fn != nil && !isSuperseded(fn, superseded) {
+			index.add(fn)
+		}
+	}
+	return index
+}
+
+// add indexes the function and its dynamic calls.
+func (s *callIndex) add(fn *ssa.Function) {
+	if fn.Signature.Recv() == nil {
+		// package initializers can not be called dynamically
+		if fn.Name() != "init" || fn.Synthetic != "package initializer" {
+			funcs, _ := s.funcsBySig.At(fn.Signature).([]*ssa.Function)
+			s.funcsBySig.Set(fn.Signature, append(funcs, fn))
+		}
+	} else if obj := fn.Object(); obj != nil {
+		id := obj.(*types.Func).Id()
+		s.methodsByID[id] = append(s.methodsByID[id], fn)
+	}
+
+	forEachCall(fn, func(site ssa.CallInstruction) {
+		call := site.Common()
+		if call.IsInvoke() {
+			id := call.Method.Id()
+			s.invokes[id] = append(s.invokes[id], dynamicCall{caller: fn, site: site})
+			return
+		}
+		if _, ok := call.Value.(*ssa.Builtin); ok || call.StaticCallee() != nil {
+			return
+		}
+		calls, _ := s.funcCalls.At(call.Signature()).([]dynamicCall)
+		s.funcCalls.Set(call.Signature(), append(calls, dynamicCall{caller: fn, site: site}))
+	})
+}
+
+// remove drops all functions and calls of the superseded packages from the index.
+func (s *callIndex) remove(superseded map[*types.Package]bool) {
+	keepFuncs := func(fns []*ssa.Function) []*ssa.Function {
+		kept := fns[:0]
+		for _, fn := range fns {
+			if !isSuperseded(fn, superseded) {
+				kept = append(kept, fn)
+			}
+		}
+		return kept
+	}
+	keepCalls := func(calls []dynamicCall) []dynamicCall {
+		kept := calls[:0]
+		for _, call := range calls {
+			if !isSuperseded(call.caller, superseded) {
+				kept = append(kept, call)
+			}
+		}
+		return kept
+	}
+
+	for _, sig := range s.funcsBySig.Keys() {
+		s.funcsBySig.Set(sig, keepFuncs(s.funcsBySig.At(sig).([]*ssa.Function)))
+	}
+	for id, fns := range s.methodsByID {
+		s.methodsByID[id] = keepFuncs(fns)
+	}
+	for _, sig := range s.funcCalls.Keys() {
+		s.funcCalls.Set(sig, keepCalls(s.funcCalls.At(sig).([]dynamicCall)))
+	}
+	for id, calls := range s.invokes {
+		s.invokes[id] = keepCalls(calls)
+	}
+}
+
+// callees returns the functions the dynamic call might call.
+func (s *callIndex) callees(site ssa.CallInstruction) []*ssa.Function {
+	call := site.Common()
+	if call.IsInvoke() {
+		iface := call.Value.Type().Underlying().(*types.Interface)
+		var impls []*ssa.Function
+		for _, fn := range s.methodsByID[call.Method.Id()] {
+			if types.Implements(fn.Signature.Recv().Type(), iface) {
+				impls = append(impls, fn)
+			}
+		}
+		return impls
+	}
+	if _, ok := call.Value.(*ssa.Builtin); ok {
+		return nil
+	}
+	funcs, _ := s.funcsBySig.At(call.Signature()).([]*ssa.Function)
+	return funcs
+}
+
+// callers returns the dynamic calls that might call the function.
+func (s *callIndex) callers(fn *ssa.Function) []dynamicCall {
+	if fn.Signature.Recv() == nil {
+		if fn.Name() == "init" && fn.Synthetic == "package initializer" {
+			return nil
+		}
+		calls, _ := s.funcCalls.At(fn.Signature).([]dynamicCall)
+		return calls
+	}
+	obj := fn.Object()
+	if obj == nil {
+		return nil
+	}
+
+	var calls []dynamicCall
+	for _, call := range s.invokes[obj.(*types.Func).Id()] {
+		iface := call.site.Common().Value.Type().Underlying().(*types.Interface)
+		if types.Implements(fn.Signature.Recv().Type(), iface) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// packageOfFile returns the module package the given file belongs to and its index in the package files.
+func (s *Module) packageOfFile(fileName string) (*packages.Package, int) {
+	for _, pack := range s.Packages {
+		for i, name := range pack.CompiledGoFiles {
+			if name == fileName {
+				return pack, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+// dependents returns the given package and all packages importing it directly or indirectly.
+// Every package is listed after the packages it imports.
+func (s *Module) dependents(pack *packages.Package) []*packages.Package {
+	var (
+		ordered  []*packages.Package
+		affected = map[*packages.Package]bool{pack: true}
+		visited  = map[*packages.Package]bool{}
+		visit    func(p *packages.Package) bool
+	)
+	visit = func(p *packages.Package) bool {
+		if visited[p] {
+			return affected[p]
+		}
+		visited[p] = true
+
+		for _, imp := range p.Imports {
+			if visit(imp) {
+				affected[p] = true
+			}
+		}
+		if affected[p] {
+			ordered = append(ordered, p)
+		}
+		return affected[p]
+	}
+
+	for _, p := range s.Packages {
+		visit(p)
+	}
+	return ordered
+}
+
+// typeCheck type-checks the syntax of the package again, resolving imports to the already checked packages.
+func (s *Module) typeCheck(pack *packages.Package) {
+	errs := pack.Errors[:0:0]
+	for _, e := range pack.Errors {
+		if e.Kind != packages.TypeError {
+			errs = append(errs, e)
+		}
+	}
+	pack.TypeErrors = nil
+
+	conf := &types.Config{
+		Importer: importerFunc(func(importPath string) (*types.Package, error) {
+			if importPath == "unsafe" {
+				return types.Unsafe, nil
+			}
+			imp, ok := pack.Imports[importPath]
+			if !ok || imp.Types == nil {
+				return nil, errors.Errorf("package %s not loaded", importPath)
+			}
+			return imp.Types, nil
+		}),
+		Sizes: pack.TypesSizes,
+		Error: func(err error) {
+			typeErr, ok := err.(types.Error)
+			if !ok {
+				return
+			}
+			pack.TypeErrors = append(pack.TypeErrors, typeErr)
+			errs = append(errs, packages.Error{
+				Pos:  typeErr.Fset.Position(typeErr.Pos).String(),
+				Msg:  typeErr.Msg,
+				Kind: packages.TypeError,
+			})
+		},
+	}
+
+	info := &types.Info{
+		Types:        map[ast.Expr]types.TypeAndValue{},
+		Defs:         map[*ast.Ident]types.Object{},
+		Uses:         map[*ast.Ident]types.Object{},
+		Implicits:    map[ast.Node]types.Object{},
+		Instances:    map[*ast.Ident]types.Instance{},
+		Scopes:       map[ast.Node]*types.Scope{},
+		Selections:   map[*ast.SelectorExpr]*types.Selection{},
+		FileVersions: map[*ast.File]string{},
+	}
+	if pack.Module != nil && pack.Module.GoVersion != "" {
+		conf.GoVersion = "go" + pack.Module.GoVersion
+	}
+	typesPkg, _ := conf.Check(pack.PkgPath, s.FSet, pack.Syntax, info)
+
+	pack.Types = typesPkg
+	pack.TypesInfo = info
+	pack.Errors = errs
+	pack.IllTyped = len(errs) != 0
+	for _, imp := range pack.Imports {
+		if imp.IllTyped {
+			pack.IllTyped = true
+		}
+	}
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
@@ -0,0 +1,193 @@
+package astrav
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"weak"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	modShapes = "github.com/tehsphinx/astrav/testdata/module/shapes"
+	modRender = "github.com/tehsphinx/astrav/testdata/module/render"
+	modText   = "github.com/tehsphinx/astrav/testdata/module/text"
+)
+
+func loadTestModule(t *testing.T) *Module {
+	dir, err := filepath.Abs("testdata/module")
+	if err != nil {
+		t.Fatal(err)
+	}
+	module := NewModule(dir)
+	if r := module.Load(); r != nil {
+		t.Fatal(r)
+	}
+	return module
+}
+
+func TestModule_UpdateFile(t *testing.T) {
+	module := loadTestModule(t)
+	text := module.Package(modText)
+	render := module.Package(modRender)
+	assert.Nil(t, module.Package(modShapes).FuncDeclByName("Perimeter"))
+
+	changed, err := module.UpdateFile("testdata/module/shapes/shapes.go", []byte(`package shapes
+
+// Square is a square shape.
+type Square struct {
+	Side float64
+}
+
+// Area returns the area of the square.
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+// Perimeter returns the perimeter of the square.
+func (s Square) Perimeter() float64 {
+	return 4 * s.Side
+}
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{modRender, modShapes}, changed)
+
+	assert.Same(t, text, module.Package(modText))
+	assert.NotSame(t, render, module.Package(modRender))
+
+	shapes := module.Package(modShapes)
+	perimeter := shapes.FuncDeclByName("Perimeter")
+	if assert.NotNil(t, perimeter) {
+		assert.Equal(t, "func() float64", perimeter.Signature().String())
+	}
+	assert.Contains(t, string(shapes.GetSource()), "4 * s.Side")
+
+	call := module.Package(modRender).FindFirstByNodeType(NodeTypeCallExpr).(*CallExpr)
+	assert.Equal(t, shapes.FuncDeclByName("Area"), call.Callee().Decl)
+
+	var found bool
+	for fn := range module.Graph.Nodes {
+		if fn != nil && fn.Name() == "Perimeter" {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestModule_UpdateFile_CallGraph(t *testing.T) {
+	src := []byte(`package shapes
+
+// Square is a square shape.
+type Square struct {
+	Side float64
+}
+
+// Area returns the area of the square.
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+// Write implements io.Writer.
+func (s *Square) Write(p []byte) (int, error) {
+	s.Side = float64(len(p))
+	return len(p), nil
+}
+`)
+
+	module := loadTestModule(t)
+	files := countFiles(module)
+	_, err := module.UpdateFile("testdata/module/shapes/shapes.go", src)
+	assert.NoError(t, err)
+	_, err = module.UpdateFile("testdata/module/shapes/shapes.go", src)
+	assert.NoError(t, err)
+	assert.Equal(t, files, countFiles(module))
+
+	// a full rebuild of the updated packages has to result in the same call graph
+	rebuilt := loadTestModule(t)
+	_, err = rebuilt.UpdateFile("testdata/module/shapes/shapes.go", src)
+	assert.NoError(t, err)
+	rebuilt.buildCallGraph()
+
+	edges := graphEdges(module)
+	assert.Equal(t, graphEdges(rebuilt), edges)
+	assert.Contains(t, edges, "io.WriteString -> (*github.com/tehsphinx/astrav/testdata/module/shapes.Square).Write")
+
+	ids := map[int]bool{}
+	for _, node := range module.Graph.Nodes {
+		assert.False(t, ids[node.ID], "duplicate node id %d", node.ID)
+		ids[node.ID] = true
+	}
+}
+
+func TestModule_UpdateFile_Rebuild(t *testing.T) {
+	module := loadTestModule(t)
+	src, err := os.ReadFile("testdata/module/shapes/shapes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typesPkg := weak.Make(module.Package(modShapes).Types())
+	ssaPkg := weak.Make(module.program.Package(module.Package(modShapes).Types()))
+
+	for i := 0; i <= maxSuperseded; i++ {
+		_, err = module.UpdateFile("testdata/module/shapes/shapes.go", src)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(module.superseded), maxSuperseded)
+	}
+
+	// the program was built from scratch: the replaced packages are not reachable anymore
+	runtime.GC()
+	assert.Nil(t, typesPkg.Value())
+	assert.Nil(t, ssaPkg.Value())
+
+	assert.Equal(t, graphEdges(loadTestModule(t)), graphEdges(module))
+}
+
+func countFiles(module *Module) int {
+	var files int
+	module.FSet.Iterate(func(*token.File) bool {
+		files++
+		return true
+	})
+	return files
+}
+
+func TestModule_UpdateFile_TypeErrors(t *testing.T) {
+	module := loadTestModule(t)
+
+	changed, err := module.UpdateFile("testdata/module/text/text.go", []byte(`package text
+
+import "strings"
+
+// Shout returns s in upper case.
+func Shout(s string) int {
+	return strings.ToUpper(s)
+}
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{modText}, changed)
+	assert.Len(t, module.Package(modText).pack.TypeErrors, 1)
+	assert.True(t, module.Package(modText).pack.IllTyped)
+}
+
+func TestModule_UpdateFile_Errors(t *testing.T) {
+	module := loadTestModule(t)
+	shapes := module.Package(modShapes)
+
+	files := countFiles(module)
+
+	_, err := module.UpdateFile("testdata/module/shapes/missing.go", []byte("package shapes\n"))
+	assert.Error(t, err)
+
+	_, err = module.UpdateFile("testdata/module/shapes/shapes.go", []byte("package shapes\n\nfunc {"))
+	assert.Error(t, err)
+
+	_, err = module.UpdateFile("testdata/module/shapes/shapes.go", []byte("package shapes\n\nimport \"fmt\"\n\nvar _ = fmt.Sprint\n"))
+	assert.Error(t, err)
+
+	assert.Same(t, shapes, module.Package(modShapes))
+	assert.Equal(t, files, countFiles(module))
+}
@@ -27,29 +27,29 @@ func (s *baseNode) getType(node ast.Node) types.Type {
 
 // ValueType returns the value type of the node.
 func (s *Field) ValueType() types.Type {
-	return s.getType(s.Type)
+	return s.getType(s.Field.Type)
 }
 
 // ValueType returns the value type of the node.
 func (s *CompositeLit) ValueType() types.Type {
-	return s.getType(s.Type)
+	return s.getType(s.CompositeLit.Type)
 }
 
 // ValueType returns the value type of the node.
 func (s *TypeAssertExpr) ValueType() types.Type {
-	return s.getType(s.Type)
+	return s.getType(s.TypeAssertExpr.Type)
 
 }
 
 // ValueType returns the value type of the node.
 func (s *ValueSpec) ValueType() types.Type {
-	return s.getType(s.Type)
+	return s.getType(s.ValueSpec.Type)
 
 }
 
 // ValueType returns the value type of the node.
 func (s *TypeSpec) ValueType() types.Type {
-	return s.getType(s.Type)
+	return s.getType(s.TypeSpec.Type)
 
 }
 
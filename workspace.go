@@ -0,0 +1,154 @@
+package astrav
+
+import (
+	"go/token"
+	"go/version"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+)
+
+// NewWorkspace creates an analyzer for several modules that are loaded into a single view as if they
+// were listed in a go.work file. Pkgs then contains the packages of all modules, they share one FileSet
+// and the call graph contains calls across module boundaries. Relative directories are resolved against
+// the working directory. The go command rejects -mod flags in workspace mode: they are removed from
+// GOFLAGS when loading.
+func NewWorkspace(dirs ...string) *Module {
+	modDirs := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		modDirs = append(modDirs, dir)
+	}
+
+	var dir string
+	if len(modDirs) != 0 {
+		dir = modDirs[0]
+	}
+	return &Module{
+		dir:     dir,
+		modDirs: modDirs,
+		FSet:    token.NewFileSet(),
+		Pkgs:    map[string]*Package{},
+	}
+}
+
+// NewGoWork creates an analyzer for all modules used by the given go.work file.
+// See NewWorkspace for details.
+func NewGoWork(path string) (*Module, error) {
+	workFile, err := filepath.Abs(path)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to resolve %s", path)
+	}
+	bts, err := os.ReadFile(workFile)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed reading %s", workFile)
+	}
+	work, err := modfile.ParseWork(workFile, bts, nil)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed parsing %s", workFile)
+	}
+	if len(work.Use) == 0 {
+		return nil, errors.Errorf("%s does not use any module", workFile)
+	}
+
+	dir := filepath.Dir(workFile)
+	dirs := make([]string, 0, len(work.Use))
+	for _, use := range work.Use {
+		modDir := use.Path
+		if !filepath.IsAbs(modDir) {
+			modDir = filepath.Join(dir, modDir)
+		}
+		dirs = append(dirs, modDir)
+	}
+
+	module := NewWorkspace(dirs...)
+	module.dir = dir
+	module.workFile = workFile
+	return module, nil
+}
+
+// Dirs returns the directories of all modules loaded by the module or workspace.
+func (s *Module) Dirs() []string {
+	if len(s.modDirs) == 0 {
+		return []string{s.dir}
+	}
+	return s.modDirs
+}
+
+// workspaceEnv returns the environment to load the packages of a workspace with. A temporary go.work file
+// is created if the workspace was not created from a go.work file. The returned function removes it again.
+// A module without further directories is loaded with the default environment.
+func (s *Module) workspaceEnv() ([]string, func(), error) {
+	if len(s.modDirs) == 0 {
+		return nil, func() {}, nil
+	}
+
+	workFile, cleanup := s.workFile, func() {}
+	if workFile == "" {
+		tmpDir, err := os.MkdirTemp("", "astrav")
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "failed creating go.work")
+		}
+		cleanup = func() { _ = os.RemoveAll(tmpDir) }
+
+		workFile = filepath.Join(tmpDir, "go.work")
+		if r := writeGoWork(workFile, s.modDirs); r != nil {
+			cleanup()
+			return nil, nil, r
+		}
+	}
+
+	// -mod flags are not allowed in workspace mode. GOFLAGS may also be set with go env -w, the filtered
+	// effective value is passed explicitly.
+	goEnv, err := s.goEnv("GOFLAGS")
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	var flags []string
+	for _, flag := range strings.Fields(goEnv["GOFLAGS"]) {
+		if !strings.HasPrefix(flag, "-mod=") {
+			flags = append(flags, flag)
+		}
+	}
+	env := append(os.Environ(), "GOWORK="+workFile, "GOFLAGS="+strings.Join(flags, " "))
+	return env, cleanup, nil
+}
+
+// writeGoWork writes a go.work file using all given module directories. Its go version is the highest
+// version required by one of the modules.
+func writeGoWork(workFile string, dirs []string) error {
+	work := &modfile.WorkFile{Syntax: &modfile.FileSyntax{}}
+
+	goVersion := "1.21"
+	for _, dir := range dirs {
+		bts, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return errors.WithMessagef(err, "%s is not a module", dir)
+		}
+		mod, err := modfile.ParseLax(filepath.Join(dir, "go.mod"), bts, nil)
+		if err != nil {
+			return errors.WithMessagef(err, "failed parsing go.mod of %s", dir)
+		}
+		if mod.Go != nil && version.Compare("go"+mod.Go.Version, "go"+goVersion) > 0 {
+			goVersion = mod.Go.Version
+		}
+
+		if r := work.AddUse(dir, ""); r != nil {
+			return errors.WithMessagef(r, "failed adding %s to go.work", dir)
+		}
+	}
+	if r := work.AddGoStmt(goVersion); r != nil {
+		return errors.WithMessage(r, "failed creating go.work")
+	}
+
+	if r := os.WriteFile(workFile, modfile.Format(work.Syntax), 0o644); r != nil {
+		return errors.WithMessage(r, "failed writing go.work")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package astrav
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertWorkspace(t *testing.T, module *Module) {
+	if r := module.Load(); r != nil {
+		t.Fatal(r)
+	}
+
+	assert.Len(t, module.Pkgs, 2)
+	geometry := module.Package("example.com/alpha/geometry")
+	report := module.Package("example.com/beta/report")
+	if !assert.NotNil(t, geometry) || !assert.NotNil(t, report) {
+		return
+	}
+	assert.Len(t, geometry.GetRawFiles(), 1)
+
+	call := report.FindFirstByNodeType(NodeTypeCallExpr).(*CallExpr)
+	assert.Equal(t, geometry.FuncDeclByName("Area"), call.Callee().Decl)
+
+	var callees []string
+	for fn, node := range module.Graph.Nodes {
+		if fn == nil || fn.Name() != "Total" {
+			continue
+		}
+		for _, edge := range node.Out {
+			callees = append(callees, edge.Callee.Func.RelString(nil))
+		}
+	}
+	assert.Equal(t, []string{"(example.com/alpha/geometry.Circle).Area"}, callees)
+}
+
+func TestNewGoWork(t *testing.T) {
+	module, err := NewGoWork("testdata/workspace/go.work")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, module.Dirs(), 2)
+	assertWorkspace(t, module)
+
+	_, err = NewGoWork("testdata/workspace/missing.work")
+	assert.Error(t, err)
+}
+
+func TestNewWorkspace(t *testing.T) {
+	module := NewWorkspace("testdata/workspace/alpha", "testdata/workspace/beta")
+	if assert.Len(t, module.Dirs(), 2) {
+		assert.True(t, filepath.IsAbs(module.Dirs()[0]))
+	}
+	assertWorkspace(t, module)
+}
+
+func TestNewWorkspace_NoModule(t *testing.T) {
+	module := NewWorkspace("testdata/workspace/alpha", "testdata/structs")
+	assert.Error(t, module.Load())
+}
+
+func TestNewGoWork_ModFlags(t *testing.T) {
+	// -mod flags of the environment do not apply to workspaces and are dropped
+	t.Setenv("GOFLAGS", "-mod=vendor -tags=astrav")
+
+	module, err := NewGoWork("testdata/workspace/go.work")
+	if !assert.NoError(t, err) {
+		return
+	}
+	env, cleanup, err := module.workspaceEnv()
+	if !assert.NoError(t, err) {
+		return
+	}
+	cleanup()
+	assert.Contains(t, env, "GOFLAGS=-tags=astrav")
+	assertWorkspace(t, module)
+}
+
+func TestNewGoWork_ModFlagsGoEnv(t *testing.T) {
+	// GOFLAGS set with go env -w are dropped as well
+	goEnvFile := filepath.Join(t.TempDir(), "env")
+	if err := os.WriteFile(goEnvFile, []byte("GOFLAGS=-mod=vendor -tags=astrav\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GOENV", goEnvFile)
+	t.Setenv("GOFLAGS", "")
+
+	module, err := NewGoWork("testdata/workspace/go.work")
+	if !assert.NoError(t, err) {
+		return
+	}
+	env, cleanup, err := module.workspaceEnv()
+	if !assert.NoError(t, err) {
+		return
+	}
+	cleanup()
+	assert.Contains(t, env, "GOFLAGS=-tags=astrav")
+	assertWorkspace(t, module)
+}